@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/leschuster/deepl-cli/pkg/config"
+)
+
+// Inspect or clear the translation cache.
+// Returns the exit code.
+func runCache(cfg config.Config, args []string) int {
+	usage := func() {
+		fmt.Fprint(os.Stderr, `Usage:
+  deepl-cli cache stats   Show the number and size of cached translations
+  deepl-cli cache clear   Remove all cached translations
+  deepl-cli cache path    Print the directory of the cache
+`)
+	}
+
+	if len(args) != 1 {
+		usage()
+		return exitUsage
+	}
+
+	c := newCache(cfg)
+	if c == nil {
+		fmt.Fprintln(os.Stderr, "deepl-cli: the cache is disabled or not available on this system")
+		return exitError
+	}
+
+	switch args[0] {
+	case "stats":
+		stats, err := c.Stats()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "deepl-cli:", err)
+			return exitError
+		}
+
+		fmt.Printf("Entries: %d\n", stats.Entries)
+		fmt.Printf("Size:    %.1f KiB\n", float64(stats.Size)/1024)
+		if stats.Entries > 0 {
+			fmt.Printf("Oldest:  %s\n", stats.Oldest.Format(time.DateTime))
+			fmt.Printf("Newest:  %s\n", stats.Newest.Format(time.DateTime))
+		}
+	case "clear":
+		if err := c.Clear(); err != nil {
+			fmt.Fprintln(os.Stderr, "deepl-cli:", err)
+			return exitError
+		}
+		fmt.Println("Cache cleared")
+	case "path":
+		fmt.Println(c.Dir())
+	case "help", "-h", "-help", "--help":
+		usage()
+	default:
+		fmt.Fprintf(os.Stderr, "deepl-cli: unknown cache command '%s'\n\n", args[0])
+		usage()
+		return exitUsage
+	}
+
+	return exitOK
+}
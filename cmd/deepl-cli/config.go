@@ -0,0 +1,134 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/leschuster/deepl-cli/pkg/config"
+	"github.com/leschuster/deepl-cli/ui/keys"
+	"github.com/leschuster/deepl-cli/ui/styles"
+)
+
+// Show or change settings in the config file.
+// Returns the exit code.
+func runConfig(args []string) int {
+	usage := func() {
+		fmt.Fprintf(os.Stderr, `Usage:
+  deepl-cli config get [name]           Print the value of a setting, or all settings
+  deepl-cli config set <name> <value>   Change a setting, an empty value restores the default
+  deepl-cli config path                 Print the path of the config file
+
+Settings:
+  %s
+  theme.colors.<color>, e.g. theme.colors.primary_background = "#5f00d7"
+  keys.<binding>, e.g. keys.swap = "ctrl+s,s"
+
+Themes:
+  %s
+
+Colors:
+  primary_foreground, primary_background, active_foreground, active_background, error
+
+Keybindings:
+  %s
+`, strings.Join(config.Settings, "\n  "), strings.Join(styles.Presets(), ", "), strings.Join(bindingNames(), ", "))
+	}
+
+	if len(args) == 0 {
+		usage()
+		return exitUsage
+	}
+
+	path, err := config.DefaultPath()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "deepl-cli:", err)
+		return exitError
+	}
+
+	switch args[0] {
+	case "get":
+		if len(args) > 2 {
+			usage()
+			return exitUsage
+		}
+
+		cfg, err := config.Load(path)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "deepl-cli:", err)
+			return exitError
+		}
+
+		if len(args) == 2 {
+			value, err := cfg.Get(args[1])
+			if err != nil {
+				fmt.Fprintln(os.Stderr, "deepl-cli:", err)
+				return exitUsage
+			}
+			fmt.Println(value)
+			return exitOK
+		}
+
+		for _, name := range cfg.Names() {
+			value, _ := cfg.Get(name)
+			fmt.Printf("%s = %s\n", name, value)
+		}
+	case "set":
+		if len(args) != 3 {
+			usage()
+			return exitUsage
+		}
+
+		// Refuse to overwrite a config that could not be read
+		cfg, err := config.Load(path)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "deepl-cli:", err)
+			fmt.Fprintln(os.Stderr, "Fix or remove the file to change settings.")
+			return exitError
+		}
+
+		if err := cfg.Set(args[1], args[2]); err != nil {
+			fmt.Fprintln(os.Stderr, "deepl-cli:", err)
+			return exitUsage
+		}
+
+		if _, err := keys.Load(cfg.KeyMap, cfg.Keys); err != nil {
+			fmt.Fprintln(os.Stderr, "deepl-cli:", err)
+			return exitUsage
+		}
+
+		if _, err := styles.Load(cfg.Theme.Preset, cfg.Theme.Colors, cfg.Theme.Border); err != nil {
+			fmt.Fprintln(os.Stderr, "deepl-cli:", err)
+			return exitUsage
+		}
+
+		if err := cfg.Save(path); err != nil {
+			fmt.Fprintln(os.Stderr, "deepl-cli:", err)
+			return exitError
+		}
+	case "path":
+		fmt.Println(path)
+	case "help", "-h", "-help", "--help":
+		usage()
+	default:
+		fmt.Fprintf(os.Stderr, "deepl-cli: unknown config command '%s'\n\n", args[0])
+		usage()
+		return exitUsage
+	}
+
+	return exitOK
+}
+
+// Helper function to get the names of all keybindings, sorted
+func bindingNames() []string {
+	keyMap := keys.DefaultKeyMap()
+
+	var names []string
+	for name := range keyMap.Bindings() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
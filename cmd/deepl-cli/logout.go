@@ -0,0 +1,38 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/leschuster/deepl-cli/pkg/auth"
+)
+
+// Remove the stored API key of the active profile.
+// Returns the exit code.
+func runLogout(a auth.Auth, args []string) int {
+	if len(args) > 0 {
+		fmt.Fprint(os.Stderr, `Usage: deepl-cli [--profile NAME] logout
+
+Removes the API key of the active profile from the keyring and the key file.
+The next start of 'deepl-cli' asks for a new key.
+`)
+		if args[0] == "-h" || args[0] == "-help" || args[0] == "--help" {
+			return exitOK
+		}
+		return exitUsage
+	}
+
+	if err := a.DeleteAPIKey(); err != nil {
+		fmt.Fprintln(os.Stderr, "deepl-cli:", err)
+		return exitError
+	}
+
+	fmt.Printf("Logged out of profile %s\n", a.Profile())
+
+	// Keys from the command line or the environment cannot be removed by us
+	if _, source, err := a.GetAPIKeyWithSource(); err == nil {
+		fmt.Fprintf(os.Stderr, "deepl-cli: note: an API key is still provided by %s\n", source)
+	}
+
+	return exitOK
+}
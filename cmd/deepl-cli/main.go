@@ -1,12 +1,20 @@
 package main
 
 import (
+	"errors"
+	"flag"
 	"fmt"
 	"os"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/leschuster/deepl-cli/pkg/auth"
+	"github.com/leschuster/deepl-cli/pkg/cache"
+	"github.com/leschuster/deepl-cli/pkg/config"
+	"github.com/leschuster/deepl-cli/pkg/history"
 	"github.com/leschuster/deepl-cli/ui"
+	"github.com/leschuster/deepl-cli/ui/keys"
+	"github.com/leschuster/deepl-cli/ui/styles"
 )
 
 const (
@@ -14,6 +22,19 @@ const (
 	user  = "deepl api key"
 )
 
+// Exit codes of the application
+const (
+	exitOK    = 0 // Success
+	exitError = 1 // The command failed, e.g. because a request failed
+	exitUsage = 2 // The command was invoked incorrectly
+
+	// DeepL rejected the request, see exitCodeFor
+	exitAuthFailed    = 3 // The API key is invalid
+	exitQuotaExceeded = 4 // The character limit has been reached
+	exitRateLimited   = 5 // Too many requests, try again later
+	exitTooLarge      = 6 // The request exceeds the size limit
+)
+
 func main() {
 	auth := auth.New(appId, user)
 
@@ -27,5 +48,198 @@ func main() {
 		defer f.Close()
 	}
 
-	ui.Run(auth)
+	// Parse options that apply to all commands
+	fs := flag.NewFlagSet("deepl-cli", flag.ContinueOnError)
+	fs.Usage = printUsage
+	apiKeyFile := fs.String("api-key-file", "", "read the API key from this file")
+	profile := fs.String("profile", "", "use the API key of this profile")
+	apiURL := fs.String("api-url", "", "send requests to this base URL")
+	proxy := fs.String("proxy", "", "send requests through this proxy")
+	timeout := fs.Duration("timeout", 0, "upper bound for a single request")
+	if err := fs.Parse(os.Args[1:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			os.Exit(exitOK)
+		}
+		os.Exit(exitUsage)
+	}
+	args := fs.Args()
+
+	if *apiKeyFile != "" {
+		auth.SetKeyFile(*apiKeyFile)
+	}
+
+	// The config command has to work even if the config is invalid
+	if len(args) > 0 && args[0] == "config" {
+		os.Exit(runConfig(args[1:]))
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "deepl-cli:", err)
+		os.Exit(exitError)
+	}
+
+	// Flags take precedence over the connection settings from the config
+	if *apiURL != "" {
+		cfg.API.URL = *apiURL
+	}
+	if *proxy != "" {
+		cfg.API.Proxy = *proxy
+	}
+	if *timeout != 0 {
+		cfg.API.Timeout = config.Duration(*timeout)
+	}
+	if err := cfg.Validate(); err != nil {
+		fmt.Fprintln(os.Stderr, "deepl-cli:", err)
+		os.Exit(exitUsage)
+	}
+
+	// The flag takes precedence over the active profile from the config
+	if *profile == "" {
+		*profile = cfg.ActiveProfile()
+	}
+
+	// The profile command has to work even if the active profile is unknown, so that it can be repaired
+	if len(args) > 0 && args[0] == "profile" {
+		auth.SetProfile(*profile) // The default profile is kept if the name is invalid
+		os.Exit(runProfile(auth, cfg, args[1:]))
+	}
+
+	if !cfg.HasProfile(*profile) {
+		fmt.Fprintf(os.Stderr, "deepl-cli: unknown profile '%s', see 'deepl-cli profile list'\n", *profile)
+		os.Exit(exitUsage)
+	}
+	if err := auth.SetProfile(*profile); err != nil {
+		fmt.Fprintln(os.Stderr, "deepl-cli:", err)
+		os.Exit(exitUsage)
+	}
+
+	// Run a non-interactive subcommand if requested
+	if len(args) > 0 {
+		switch args[0] {
+		case "translate":
+			os.Exit(runTranslate(auth, cfg, args[1:]))
+		case "cache":
+			os.Exit(runCache(cfg, args[1:]))
+		case "logout":
+			os.Exit(runLogout(auth, args[1:]))
+		case "help":
+			printUsage()
+			os.Exit(exitOK)
+		default:
+			fmt.Fprintf(os.Stderr, "deepl-cli: unknown command '%s'\n\n", args[0])
+			printUsage()
+			os.Exit(exitUsage)
+		}
+	}
+
+	keyMap, err := keys.Load(cfg.KeyMap, cfg.Keys)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "deepl-cli: invalid config:", err)
+		os.Exit(exitError)
+	}
+
+	styles, err := newStyles(cfg)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "deepl-cli: invalid config:", err)
+		os.Exit(exitError)
+	}
+
+	ui.Run(auth, ui.Options{
+		Cache:   newCache(cfg),
+		History: newHistory(),
+		Config:  cfg,
+		Keys:    &keyMap,
+		Styles:  styles,
+
+		Profiles: cfg.AllProfiles(),
+	})
+}
+
+// Get the config of the user, the default config if there is none
+func loadConfig() (config.Config, error) {
+	path, err := config.DefaultPath()
+	if err != nil {
+		return config.Config{}, err
+	}
+
+	return config.Load(path)
+}
+
+// Get the styles of the theme from the config.
+// The no-color theme is enforced if NO_COLOR is set, see https://no-color.org.
+func newStyles(cfg config.Config) (*styles.Styles, error) {
+	preset := cfg.Theme.Preset
+	if os.Getenv("NO_COLOR") != "" {
+		preset = styles.PresetNoColor
+	}
+
+	return styles.Load(preset, cfg.Theme.Colors, cfg.Theme.Border)
+}
+
+// Get the translation cache, nil if it is not available or disabled
+func newCache(cfg config.Config) *cache.Cache {
+	if cfg.Cache.Disabled {
+		return nil
+	}
+
+	dir := cfg.Cache.Dir
+	if dir == "" {
+		var err error
+		if dir, err = cache.DefaultDir(); err != nil {
+			return nil
+		}
+	}
+
+	ttl := cache.DefaultTTL
+	if cfg.Cache.TTL > 0 {
+		ttl = time.Duration(cfg.Cache.TTL)
+	}
+
+	maxSize := int64(cache.DefaultMaxSize)
+	if cfg.Cache.MaxSizeMB > 0 {
+		maxSize = cfg.Cache.MaxSizeMB << 20
+	}
+
+	return cache.New(dir, ttl, maxSize)
+}
+
+// Print an overview of all commands
+func printUsage() {
+	fmt.Fprint(os.Stderr, `Usage: deepl-cli [options] [command]
+
+Commands:
+  deepl-cli                 Start the interactive user interface
+  deepl-cli translate ...   Translate text from arguments or stdin
+  deepl-cli cache ...       Inspect or clear the translation cache
+  deepl-cli config ...      Show or change settings
+  deepl-cli profile ...     Manage API keys of several accounts
+  deepl-cli logout          Remove the stored API key of the active profile
+
+Options:
+  --api-key-file FILE       Read the API key from FILE
+  --profile NAME            Use the API key of profile NAME instead of the active one
+  --api-url URL             Send requests to URL instead of the endpoint of the API key
+  --proxy URL               Send requests through the proxy at URL
+  --timeout DURATION        Abort requests that take longer than DURATION, e.g. 30s
+
+The API key is taken from the first of these sources that is set:
+  1. --api-key-file
+  2. the DEEPL_AUTH_KEY environment variable
+  3. the file api-key in the config directory, readable only by you (chmod 600),
+     api-key-NAME for profiles other than the default one
+  4. the entry of the profile in the system's keyring, where logging in saves it
+
+Run 'deepl-cli <command> -h' for more information on a command.
+`)
+}
+
+// Get the translation history, nil if it is not available
+func newHistory() *history.Store {
+	path, err := history.DefaultPath()
+	if err != nil {
+		return nil
+	}
+
+	return history.New(path, history.DefaultMaxEntries)
 }
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/x/term"
+	"github.com/leschuster/deepl-cli/pkg/auth"
+	"github.com/leschuster/deepl-cli/pkg/config"
+)
+
+// Manage the profiles, each with its own API key.
+// Returns the exit code.
+func runProfile(a auth.Auth, cfg config.Config, args []string) int {
+	usage := func() {
+		fmt.Fprint(os.Stderr, `Usage:
+  deepl-cli profile list            List all profiles, the active one is marked with *
+  deepl-cli profile add <name>      Add a profile or replace its API key, read from stdin
+  deepl-cli profile remove <name>   Remove a profile and its API key
+  deepl-cli profile use <name>      Make a profile the active one
+
+Use 'deepl-cli --profile <name>' to use a profile only once.
+`)
+	}
+
+	if len(args) == 0 || (args[0] != "list" && len(args) != 2) {
+		usage()
+		return exitUsage
+	}
+
+	path, err := config.DefaultPath()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "deepl-cli:", err)
+		return exitError
+	}
+
+	switch args[0] {
+	case "list":
+		for _, name := range cfg.AllProfiles() {
+			marker := " "
+			if name == a.Profile() {
+				marker = "*"
+			}
+			fmt.Printf("%s %s\n", marker, name)
+		}
+		return exitOK
+
+	case "add":
+		name := args[1]
+		if err := a.SetProfile(name); err != nil {
+			fmt.Fprintln(os.Stderr, "deepl-cli:", err)
+			return exitUsage
+		}
+
+		key, err := readAPIKey(name)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "deepl-cli:", err)
+			return exitError
+		}
+		if err := a.SetApiKey(key); err != nil {
+			fmt.Fprintln(os.Stderr, "deepl-cli:", err)
+			return exitError
+		}
+
+		cfg.AddProfile(name)
+
+	case "remove":
+		name := args[1]
+		if !cfg.HasProfile(name) {
+			fmt.Fprintf(os.Stderr, "deepl-cli: unknown profile '%s'\n", name)
+			return exitUsage
+		}
+		if name == auth.DefaultProfile {
+			fmt.Fprintln(os.Stderr, "deepl-cli: the default profile cannot be removed")
+			return exitUsage
+		}
+
+		if err := a.SetProfile(name); err != nil {
+			fmt.Fprintln(os.Stderr, "deepl-cli:", err)
+			return exitUsage
+		}
+		if err := a.DeleteAPIKey(); err != nil {
+			fmt.Fprintln(os.Stderr, "deepl-cli:", err)
+			return exitError
+		}
+
+		cfg.RemoveProfile(name)
+
+	case "use":
+		name := args[1]
+		if !cfg.HasProfile(name) {
+			fmt.Fprintf(os.Stderr, "deepl-cli: unknown profile '%s', add it with 'deepl-cli profile add %s'\n", name, name)
+			return exitUsage
+		}
+
+		cfg.Profile = name
+		if name == auth.DefaultProfile {
+			cfg.Profile = ""
+		}
+
+	case "help", "-h", "-help", "--help":
+		usage()
+		return exitOK
+
+	default:
+		fmt.Fprintf(os.Stderr, "deepl-cli: unknown profile command '%s'\n\n", args[0])
+		usage()
+		return exitUsage
+	}
+
+	if err := cfg.Save(path); err != nil {
+		fmt.Fprintln(os.Stderr, "deepl-cli:", err)
+		return exitError
+	}
+
+	return exitOK
+}
+
+// Helper function to read an API key from stdin.
+// The key is not echoed if stdin is a terminal.
+func readAPIKey(profile string) (string, error) {
+	var key string
+
+	if term.IsTerminal(os.Stdin.Fd()) {
+		fmt.Fprintf(os.Stderr, "API key for profile %s: ", profile)
+		data, err := term.ReadPassword(os.Stdin.Fd())
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return "", fmt.Errorf("could not read API key: %v", err)
+		}
+		key = string(data)
+	} else {
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && line == "" {
+			return "", fmt.Errorf("could not read API key: %v", err)
+		}
+		key = line
+	}
+
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return "", fmt.Errorf("no API key given")
+	}
+
+	return key, nil
+}
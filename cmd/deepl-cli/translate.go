@@ -0,0 +1,175 @@
+package main
+
+import (
+	stdcontext "context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/leschuster/deepl-cli/pkg/auth"
+	"github.com/leschuster/deepl-cli/pkg/config"
+	deeplapi "github.com/leschuster/deepl-cli/pkg/deepl-api"
+)
+
+// Translate text given as arguments or via stdin and print the result to stdout.
+// Returns the exit code.
+func runTranslate(auth auth.Auth, cfg config.Config, args []string) int {
+	fs := flag.NewFlagSet("translate", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), "Usage: deepl-cli translate --to LANG [options] [text...]\n\n")
+		fmt.Fprint(fs.Output(), "Translates the given text. If no text is given, it is read from stdin.\n\n")
+		fs.PrintDefaults()
+	}
+
+	to := fs.String("to", cfg.TargetLang, "target language code, e.g. DE or EN-GB (required unless set in the config)")
+	from := fs.String("from", cfg.SourceLang, "source language code, detected automatically if omitted")
+	formality := fs.String("formality", "", "formality of the translation: more, less, prefer_more, prefer_less or default (default from the config)")
+	context := fs.String("context", "", "additional context that influences the translation, but is not translated itself")
+	glossary := fs.String("glossary", "", "ID of the glossary to use, requires --from")
+	tagHandling := fs.String("tag-handling", "", "kind of markup in the text: html or xml")
+	outlineDetection := fs.Bool("outline-detection", true, "detect the XML structure automatically, set to false to only split at --splitting-tags")
+	nonSplittingTags := fs.String("non-splitting-tags", "", "comma-separated XML tags that never split sentences")
+	splittingTags := fs.String("splitting-tags", "", "comma-separated XML tags that always split sentences")
+	ignoreTags := fs.String("ignore-tags", "", "comma-separated XML tags whose content is not translated")
+	splitSentences := fs.String("split-sentences", "", "split the text into sentences: 0 (never), 1 (default) or nonewlines")
+	preserveFormatting := fs.Bool("preserve-formatting", false, "keep punctuation and upper/lower case as is")
+	modelType := fs.String("model-type", "", "model to use: quality_optimized, prefer_quality_optimized or latency_optimized")
+	noCache := fs.Bool("no-cache", false, "always request the translation from DeepL, bypassing the cache")
+	showBilled := fs.Bool("show-billed-characters", false, "print the number of billed characters to stderr")
+	concurrency := fs.Int("concurrency", deeplapi.DefaultBatchConcurrency, "maximum number of parallel requests for large inputs")
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+
+	// The config applies to all target languages, so it must not fail for those without formality
+	if *formality == "" {
+		*formality = preferFormality(cfg.Formality)
+	}
+
+	if *to == "" {
+		fmt.Fprintln(os.Stderr, "deepl-cli: no target language given, use --to")
+		fs.Usage()
+		return exitUsage
+	}
+
+	text, err := readInput(fs.Args(), os.Stdin)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "deepl-cli:", err)
+		return exitError
+	}
+	if strings.TrimSpace(text) == "" {
+		fmt.Fprintln(os.Stderr, "deepl-cli: no text to translate")
+		return exitUsage
+	}
+
+	apiKey, err := auth.GetAPIKey()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "deepl-cli:", err)
+		return exitError
+	}
+	api := deeplapi.New(apiKey, cfg.APIOptions()...)
+
+	// Abort the request on Ctrl+C
+	ctx, stop := signal.NotifyContext(stdcontext.Background(), os.Interrupt)
+	defer stop()
+
+	params := deeplapi.TranslateParams{
+		Text:       []string{text},
+		SourceLang: strings.ToUpper(*from),
+		TargetLang: strings.ToUpper(*to),
+		Context:    *context,
+		Formality:  *formality,
+		GlossaryID: *glossary,
+
+		TagHandling:          *tagHandling,
+		NonSplittingTags:     config.SplitList(*nonSplittingTags),
+		SplittingTags:        config.SplitList(*splittingTags),
+		IgnoreTags:           config.SplitList(*ignoreTags),
+		SplitSentences:       *splitSentences,
+		PreserveFormatting:   *preserveFormatting,
+		ModelType:            *modelType,
+		ShowBilledCharacters: *showBilled,
+	}
+
+	// Only send outline_detection if it differs from DeepL's default
+	if !*outlineDetection {
+		params.OutlineDetection = outlineDetection
+	}
+
+	translate := func(ctx stdcontext.Context, p deeplapi.TranslateParams) (*deeplapi.TranslateResp, error) {
+		return api.TranslateBatchCtx(ctx, p, *concurrency)
+	}
+
+	var resp *deeplapi.TranslateResp
+	if c := newCache(cfg); c != nil && !*noCache {
+		resp, err = c.Translate(ctx, api.BaseURL(), params, translate)
+	} else {
+		resp, err = translate(ctx, params)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "deepl-cli: failed to fetch translation:", err)
+		return exitCodeFor(err)
+	}
+
+	for _, t := range resp.Translations {
+		fmt.Fprintln(os.Stdout, strings.TrimSuffix(t.Text, "\n"))
+
+		if *showBilled {
+			fmt.Fprintln(os.Stderr, "billed characters:", t.BilledCharacters)
+		}
+	}
+
+	return exitOK
+}
+
+// Helper function to get the text to translate.
+// Arguments take precedence over stdin.
+func readInput(args []string, stdin io.Reader) (string, error) {
+	if len(args) > 0 {
+		return strings.Join(args, " "), nil
+	}
+
+	data, err := io.ReadAll(stdin)
+	if err != nil {
+		return "", fmt.Errorf("could not read from stdin: %v", err)
+	}
+
+	return string(data), nil
+}
+
+// Helper function to turn a formality into one that falls back to the default
+// for target languages without formality support, e.g. more becomes prefer_more
+func preferFormality(formality string) string {
+	switch formality {
+	case deeplapi.FormalityMore:
+		return deeplapi.FormalityPreferMore
+	case deeplapi.FormalityLess:
+		return deeplapi.FormalityPreferLess
+	default:
+		return formality
+	}
+}
+
+// Helper function to map an error to the exit code describing its class
+func exitCodeFor(err error) int {
+	switch {
+	case deeplapi.IsAuthFailed(err):
+		return exitAuthFailed
+	case deeplapi.IsQuotaExceeded(err):
+		return exitQuotaExceeded
+	case deeplapi.IsRateLimited(err):
+		return exitRateLimited
+	case deeplapi.IsTooLarge(err):
+		return exitTooLarge
+	default:
+		return exitError
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/leschuster/deepl-cli/pkg/auth"
+	"github.com/leschuster/deepl-cli/pkg/config"
+	"github.com/leschuster/deepl-cli/pkg/deepl-api/fake"
+)
+
+// Helper function to get auth and config that translate with a fake DeepL server
+func fakeSetup(t *testing.T) (auth.Auth, config.Config) {
+	t.Helper()
+
+	srv := fake.NewServer()
+	t.Cleanup(srv.Close)
+
+	keyFile := filepath.Join(t.TempDir(), "api-key")
+	if err := os.WriteFile(keyFile, []byte(fake.AuthKey), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	a := auth.New(appId+".test", user)
+	a.SetKeyFile(keyFile)
+
+	cfg := config.Config{}
+	cfg.API.URL = srv.URL
+	cfg.Cache.Disabled = true
+
+	return a, cfg
+}
+
+func TestFormalityFromConfigFallsBack(t *testing.T) {
+	a, cfg := fakeSetup(t)
+	cfg.Formality = "more"
+
+	// EN-GB does not support formality
+	if code := runTranslate(a, cfg, []string{"--to", "EN-GB", "Hallo"}); code != exitOK {
+		t.Errorf("got exit code %d, want %d", code, exitOK)
+	}
+}
+
+func TestFormalityFlagIsSentAsIs(t *testing.T) {
+	a, cfg := fakeSetup(t)
+
+	if code := runTranslate(a, cfg, []string{"--to", "EN-GB", "--formality", "more", "Hallo"}); code != exitError {
+		t.Errorf("got exit code %d, want %d", code, exitError)
+	}
+}
+
+func TestPreferFormality(t *testing.T) {
+	tests := map[string]string{
+		"":            "",
+		"more":        "prefer_more",
+		"less":        "prefer_less",
+		"prefer_more": "prefer_more",
+		"default":     "default",
+	}
+
+	for in, want := range tests {
+		if got := preferFormality(in); got != want {
+			t.Errorf("preferFormality(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
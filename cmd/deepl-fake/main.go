@@ -0,0 +1,36 @@
+// Command deepl-fake serves the fake DeepL API for offline development.
+//
+//	go run ./cmd/deepl-fake --latency 300ms
+//	DEEPL_AUTH_KEY=fake-auth-key:fx deepl-cli --api-url http://localhost:8080/v2
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/leschuster/deepl-cli/pkg/deepl-api/fake"
+)
+
+func main() {
+	addr := flag.String("addr", "localhost:8080", "address to listen on")
+	apiKey := flag.String("api-key", fake.AuthKey, "API key to accept")
+	latency := flag.Duration("latency", 0, "delay of every response, e.g. 300ms")
+	limit := flag.Int64("character-limit", fake.DefaultCharacterLimit, "number of characters that can be translated, 0 for no limit")
+	flag.Parse()
+
+	h := fake.NewHandler(
+		fake.WithAuthKey(*apiKey),
+		fake.WithLatency(*latency),
+		fake.WithCharacterLimit(*limit),
+	)
+
+	fmt.Fprintf(os.Stderr, "Serving the fake DeepL API at http://%s/v2 with API key %s\n", *addr, *apiKey)
+
+	if err := http.ListenAndServe(*addr, h); err != nil {
+		fmt.Fprintln(os.Stderr, "deepl-fake:", err)
+		os.Exit(1)
+	}
+}
@@ -4,33 +4,276 @@
 package auth
 
 import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
 	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
 
 	"github.com/zalando/go-keyring"
 )
 
+// Environment variable that holds the API key, e.g. in CI jobs
+const EnvAPIKey = "DEEPL_AUTH_KEY"
+
+// Where an API key has been found
+type Source string
+
+const (
+	SourceKeyFile = Source("--api-key-file") // File given on the command line
+	SourceEnv     = Source(EnvAPIKey)        // Environment variable
+	SourceFile    = Source("api key file")   // File in the config directory
+	SourceKeyring = Source("keyring")        // System's keyring
+)
+
+// Name of the profile used if no other profile is selected
+const DefaultProfile = "default"
+
 type Auth struct {
 	service string // name of the application
 	user    string // username
+	profile string // name of the profile whose key is used
+	keyFile string // file given on the command line, optional
 }
 
 func New(service, user string) Auth {
 	return Auth{
 		service: service,
 		user:    user,
+		profile: DefaultProfile,
 	}
 }
 
-func (a *Auth) SetApiKey(apikey string) error {
-	err := keyring.Set(a.service, a.user, apikey)
-	if err != nil {
+// Use the API key of another profile.
+// Each profile has its own entry in the keyring and its own key file.
+func (a *Auth) SetProfile(name string) error {
+	if err := ValidateProfile(name); err != nil {
 		return err
 	}
 
-	log.Println("successfully saved apikey in keyring")
+	a.profile = name
+	return nil
+}
+
+// Get the name of the profile in use
+func (a *Auth) Profile() string {
+	return a.profile
+}
+
+// Check whether name can be used as name of a profile
+func ValidateProfile(name string) error {
+	if name == "" {
+		return errors.New("profile name must not be empty")
+	}
+
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_') {
+			return fmt.Errorf("invalid profile name '%s', use letters, digits, '-' and '_' only", name)
+		}
+	}
+
+	return nil
+}
+
+// Read the API key from this file before looking anywhere else
+func (a *Auth) SetKeyFile(path string) {
+	a.keyFile = path
+}
+
+// Save the API key in the keyring.
+// If there is no keyring, e.g. on a headless server, it is saved to a file
+// in the config directory that only the user can read instead.
+// A key file saved before is removed, because it would take precedence over the keyring.
+func (a *Auth) SetApiKey(apikey string) error {
+	path, pathErr := a.keyFilePath()
+
+	err := keyring.Set(a.service, a.keyringUser(), apikey)
+	if err == nil {
+		log.Println("successfully saved apikey in keyring")
+
+		if pathErr == nil {
+			if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return fmt.Errorf("saved API key in keyring, but could not remove the old key in %s: %v", path, err)
+			}
+		}
+		return nil
+	}
+
+	if pathErr != nil {
+		return fmt.Errorf("could not save API key in keyring: %v", err)
+	}
+
+	if fileErr := writeKeyFile(path, apikey); fileErr != nil {
+		return fmt.Errorf("could not save API key in keyring (%v) or file (%v)", err, fileErr)
+	}
+
+	log.Println("keyring not available, saved apikey in", path)
 	return nil
 }
 
+// Get the API key, see GetAPIKeyWithSource for the order of precedence
 func (a *Auth) GetAPIKey() (string, error) {
-	return keyring.Get(a.service, a.user)
+	key, _, err := a.GetAPIKeyWithSource()
+	return key, err
+}
+
+// Get where an API key is provided that takes precedence over the key saved
+// with SetApiKey, i.e. the file given with SetKeyFile or DEEPL_AUTH_KEY
+func (a *Auth) Override() (Source, bool) {
+	switch {
+	case a.keyFile != "":
+		return SourceKeyFile, true
+	case strings.TrimSpace(os.Getenv(EnvAPIKey)) != "":
+		return SourceEnv, true
+	default:
+		return "", false
+	}
+}
+
+// Get the API key and where it has been found. The first one found is used:
+//  1. the file given with SetKeyFile (--api-key-file)
+//  2. the DEEPL_AUTH_KEY environment variable
+//  3. the file of the profile in the config directory, see DefaultKeyFilePath
+//  4. the keyring entry of the profile
+func (a *Auth) GetAPIKeyWithSource() (string, Source, error) {
+	if a.keyFile != "" {
+		key, err := readKeyFile(a.keyFile, false)
+		if err != nil {
+			return "", "", err
+		}
+		return key, SourceKeyFile, nil
+	}
+
+	if key := strings.TrimSpace(os.Getenv(EnvAPIKey)); key != "" {
+		return key, SourceEnv, nil
+	}
+
+	if path, err := a.keyFilePath(); err == nil {
+		key, err := readKeyFile(path, true)
+		if err == nil {
+			return key, SourceFile, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return "", "", err
+		}
+	}
+
+	key, err := keyring.Get(a.service, a.keyringUser())
+	if err != nil {
+		return "", "", fmt.Errorf("no API key found for profile %s, log in or set %s (keyring: %v)", a.profile, EnvAPIKey, err)
+	}
+
+	return key, SourceKeyring, nil
+}
+
+// DefaultKeyFilePath returns the file in the config directory the API key of the default
+// profile may be stored in, e.g. $XDG_CONFIG_HOME/deepl-cli/api-key on Linux.
+// Other profiles use the same path with the name of the profile as suffix.
+func DefaultKeyFilePath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("could not determine config directory: %v", err)
+	}
+
+	return filepath.Join(dir, "deepl-cli", "api-key"), nil
+}
+
+// Remove the API key of the profile from the keyring and the key file.
+// Keys given with SetKeyFile or DEEPL_AUTH_KEY are not affected.
+func (a *Auth) DeleteAPIKey() error {
+	deleted := false
+
+	// The keyring may not be available, which is fine if the key is stored in a file
+	keyringErr := keyring.Delete(a.service, a.keyringUser())
+	switch {
+	case keyringErr == nil:
+		deleted = true
+	case errors.Is(keyringErr, keyring.ErrNotFound):
+		keyringErr = nil
+	}
+
+	if path, err := a.keyFilePath(); err == nil {
+		err := os.Remove(path)
+		if err == nil {
+			deleted = true
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("could not delete API key of profile %s: %v", a.profile, err)
+		}
+	}
+
+	if !deleted && keyringErr != nil {
+		return fmt.Errorf("could not delete API key of profile %s: %v", a.profile, keyringErr)
+	}
+
+	return nil
+}
+
+// Helper function to get the keyring user of the profile.
+// The default profile uses the plain user for compatibility with older versions.
+func (a *Auth) keyringUser() string {
+	if a.profile == DefaultProfile {
+		return a.user
+	}
+
+	return a.user + " (" + a.profile + ")"
+}
+
+// Helper function to get the key file of the profile, e.g. api-key-team
+func (a *Auth) keyFilePath() (string, error) {
+	path, err := DefaultKeyFilePath()
+	if err != nil || a.profile == DefaultProfile {
+		return path, err
+	}
+
+	return path + "-" + a.profile, nil
+}
+
+// Helper function to read an API key from a file.
+// If private is set, the file must not be accessible by other users.
+func readKeyFile(path string, private bool) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("could not read API key: %w", err)
+	}
+	defer f.Close()
+
+	if private && runtime.GOOS != "windows" {
+		info, err := f.Stat()
+		if err != nil {
+			return "", fmt.Errorf("could not read API key: %w", err)
+		}
+		if info.Mode().Perm()&0o077 != 0 {
+			return "", fmt.Errorf("API key file %s is accessible by other users, run 'chmod 600 %s'", path, path)
+		}
+	}
+
+	data, err := io.ReadAll(io.LimitReader(f, 4096)) // API keys are much shorter
+	if err != nil {
+		return "", fmt.Errorf("could not read API key from %s: %v", path, err)
+	}
+
+	key := strings.TrimSpace(string(data))
+	if key == "" {
+		return "", fmt.Errorf("API key file %s is empty", path)
+	}
+
+	return key, nil
+}
+
+// Helper function to write an API key to a file only the user can access
+func writeKeyFile(path, key string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(path, []byte(key+"\n"), 0o600); err != nil {
+		return err
+	}
+
+	// WriteFile keeps the permissions of an existing file
+	return os.Chmod(path, 0o600)
 }
@@ -0,0 +1,144 @@
+package auth
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/zalando/go-keyring"
+)
+
+// Helper function to get an Auth whose keyring and config directory are only used by the test
+func newTestAuth(t *testing.T) Auth {
+	t.Helper()
+
+	keyring.MockInit()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(EnvAPIKey, "")
+
+	return New("com.leschuster.deepl-cli.test", "test")
+}
+
+// Helper function to save a key in the key file of the default profile
+func writeTestKeyFile(t *testing.T, key string) string {
+	t.Helper()
+
+	path, err := DefaultKeyFilePath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := writeKeyFile(path, key); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func TestSetApiKeyReplacesOldKeyFile(t *testing.T) {
+	a := newTestAuth(t)
+	path := writeTestKeyFile(t, "old-key:fx")
+
+	if err := a.SetApiKey("new-key:fx"); err != nil {
+		t.Fatal(err)
+	}
+
+	key, source, err := a.GetAPIKeyWithSource()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key != "new-key:fx" || source != SourceKeyring {
+		t.Errorf("got %q from %s, want new-key:fx from the keyring", key, source)
+	}
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected the old key file to be removed, got %v", err)
+	}
+}
+
+func TestSetApiKeyFallsBackToFile(t *testing.T) {
+	a := newTestAuth(t)
+	writeTestKeyFile(t, "old-key:fx")
+	keyring.MockInitWithError(errors.New("no keyring"))
+
+	if err := a.SetApiKey("new-key:fx"); err != nil {
+		t.Fatal(err)
+	}
+
+	key, source, err := a.GetAPIKeyWithSource()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key != "new-key:fx" || source != SourceFile {
+		t.Errorf("got %q from %s, want new-key:fx from the key file", key, source)
+	}
+}
+
+func TestProfilesHaveTheirOwnKeys(t *testing.T) {
+	a := newTestAuth(t)
+	a.SetApiKey("default-key:fx")
+
+	if err := a.SetProfile("team"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.GetAPIKey(); err == nil {
+		t.Error("expected no key for the new profile")
+	}
+
+	a.SetApiKey("team-key")
+	if key, _ := a.GetAPIKey(); key != "team-key" {
+		t.Errorf("got %q, want team-key", key)
+	}
+
+	a.SetProfile(DefaultProfile)
+	if key, _ := a.GetAPIKey(); key != "default-key:fx" {
+		t.Errorf("got %q, want default-key:fx", key)
+	}
+}
+
+func TestOverride(t *testing.T) {
+	a := newTestAuth(t)
+	a.SetApiKey("saved-key:fx")
+
+	if source, ok := a.Override(); ok {
+		t.Errorf("got override %s, want none", source)
+	}
+
+	t.Setenv(EnvAPIKey, "env-key:fx")
+	if source, ok := a.Override(); !ok || source != SourceEnv {
+		t.Errorf("got override %s, want %s", source, SourceEnv)
+	}
+	if key, _ := a.GetAPIKey(); key != "env-key:fx" {
+		t.Errorf("got %q, want the key from the environment", key)
+	}
+
+	keyFile := filepath.Join(t.TempDir(), "key")
+	os.WriteFile(keyFile, []byte("file-key:fx\n"), 0o600)
+	a.SetKeyFile(keyFile)
+	if source, ok := a.Override(); !ok || source != SourceKeyFile {
+		t.Errorf("got override %s, want %s", source, SourceKeyFile)
+	}
+	if key, _ := a.GetAPIKey(); key != "file-key:fx" {
+		t.Errorf("got %q, want the key from --api-key-file", key)
+	}
+}
+
+func TestDeleteAPIKey(t *testing.T) {
+	a := newTestAuth(t)
+	a.SetApiKey("saved-key:fx")
+
+	if err := a.DeleteAPIKey(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.GetAPIKey(); err == nil {
+		t.Error("expected no key after deleting it")
+	}
+}
+
+func TestValidateProfile(t *testing.T) {
+	for name, valid := range map[string]bool{"team": true, "team-2_b": true, "": false, "a/b": false, "ä": false} {
+		if err := ValidateProfile(name); (err == nil) != valid {
+			t.Errorf("ValidateProfile(%q) = %v, want valid %v", name, err, valid)
+		}
+	}
+}
@@ -0,0 +1,339 @@
+// Package cache provides a persistent on-disk cache for translations.
+// Entries are keyed by the endpoint that translated them, the text, the
+// languages and all other options of the request, so that repeated
+// translations do not consume any quota.
+
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	deeplapi "github.com/leschuster/deepl-cli/pkg/deepl-api"
+)
+
+// Default limits of the cache
+const (
+	DefaultTTL     = 30 * 24 * time.Hour // Entries older than this are ignored and removed
+	DefaultMaxSize = 50 * 1024 * 1024    // in bytes, the oldest entries are removed once exceeded
+)
+
+// Minimum time between two checks of the limits, each one reads the whole directory
+const pruneInterval = 1 * time.Hour
+
+// File whose modification time tells when the limits were last checked
+const pruneMarker = ".pruned"
+
+// Function that performs the actual translation on a cache miss,
+// e.g. DeeplAPI.TranslateCtx
+type TranslateFunc func(ctx context.Context, params deeplapi.TranslateParams) (*deeplapi.TranslateResp, error)
+
+// Cache stores translations as files in a directory
+type Cache struct {
+	dir     string
+	ttl     time.Duration
+	maxSize int64
+	mu      *sync.Mutex
+}
+
+// Stats describes the current state of the cache
+type Stats struct {
+	Entries int       // Number of cached translations
+	Size    int64     // Total size in bytes
+	Oldest  time.Time // Time the oldest entry was stored, zero if empty
+	Newest  time.Time // Time the newest entry was stored, zero if empty
+}
+
+// Content of a single cache file
+type entry struct {
+	Created  time.Time              `json:"created"`
+	Response deeplapi.TranslateResp `json:"response"`
+}
+
+// Creates a new cache storing its entries in dir.
+// A ttl or maxSize of 0 disables the respective limit.
+func New(dir string, ttl time.Duration, maxSize int64) *Cache {
+	return &Cache{
+		dir:     dir,
+		ttl:     ttl,
+		maxSize: maxSize,
+		mu:      &sync.Mutex{},
+	}
+}
+
+// DefaultDir returns the directory the cache is stored in by default,
+// e.g. $XDG_CACHE_HOME/deepl-cli/translations on Linux
+func DefaultDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("could not determine cache directory: %v", err)
+	}
+
+	return filepath.Join(dir, "deepl-cli", "translations"), nil
+}
+
+// Get the directory the cache is stored in
+func (c *Cache) Dir() string {
+	return c.dir
+}
+
+// Get a cached translation. Returns false if there is none or if it expired.
+// The endpoint names where the translation came from, e.g. the base URL of the DeepL API.
+func (c *Cache) Get(endpoint string, params deeplapi.TranslateParams) (*deeplapi.TranslateResp, bool) {
+	path, err := c.path(endpoint, params)
+	if err != nil {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+
+	e := entry{}
+	if err := json.Unmarshal(data, &e); err != nil {
+		// Corrupt entry, get rid of it
+		os.Remove(path)
+		return nil, false
+	}
+
+	if c.expired(e.Created) {
+		os.Remove(path)
+		return nil, false
+	}
+
+	return &e.Response, true
+}
+
+// Store a translation of endpoint in the cache.
+// The limits of the cache are checked at most once per hour.
+func (c *Cache) Put(endpoint string, params deeplapi.TranslateParams, resp *deeplapi.TranslateResp) error {
+	path, err := c.path(endpoint, params)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(entry{
+		Created:  time.Now(),
+		Response: *resp,
+	})
+	if err != nil {
+		return fmt.Errorf("could not marshal cache entry: %v", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("could not create cache directory: %v", err)
+	}
+
+	// Write to a temporary file first so that readers never see partial entries.
+	// Its name is unique, because other processes may write the same entry.
+	if err := writeFile(path, data); err != nil {
+		return fmt.Errorf("could not write cache entry: %v", err)
+	}
+
+	if !c.pruneDue() {
+		return nil
+	}
+	return c.prune()
+}
+
+// Translate returns the cached translation for params if there is one.
+// Otherwise, it calls translate and caches its result.
+// Failing to store the result is not considered an error.
+func (c *Cache) Translate(ctx context.Context, endpoint string, params deeplapi.TranslateParams, translate TranslateFunc) (*deeplapi.TranslateResp, error) {
+	if resp, ok := c.Get(endpoint, params); ok {
+		return resp, nil
+	}
+
+	resp, err := translate(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	_ = c.Put(endpoint, params, resp)
+
+	return resp, nil
+}
+
+// Remove all entries from the cache
+func (c *Cache) Clear() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := os.RemoveAll(c.dir); err != nil {
+		return fmt.Errorf("could not clear cache: %v", err)
+	}
+
+	return nil
+}
+
+// Get statistics about the cache
+func (c *Cache) Stats() (Stats, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	files, err := c.files()
+	if err != nil {
+		return Stats{}, err
+	}
+
+	stats := Stats{Entries: len(files)}
+	for i, f := range files {
+		stats.Size += f.size
+		if i == 0 || f.modTime.Before(stats.Oldest) {
+			stats.Oldest = f.modTime
+		}
+		if i == 0 || f.modTime.After(stats.Newest) {
+			stats.Newest = f.modTime
+		}
+	}
+
+	return stats, nil
+}
+
+// Helper function to check if an entry created at the given time expired
+func (c *Cache) expired(created time.Time) bool {
+	return c.ttl > 0 && time.Since(created) > c.ttl
+}
+
+// Helper function to get the file an entry is stored in.
+// The file name is the hash of the endpoint and all request parameters.
+func (c *Cache) path(endpoint string, params deeplapi.TranslateParams) (string, error) {
+	key, err := json.Marshal(params)
+	if err != nil {
+		return "", fmt.Errorf("could not marshal cache key: %v", err)
+	}
+
+	sum := sha256.Sum256(append([]byte(endpoint+"\n"), key...))
+	name := hex.EncodeToString(sum[:])
+
+	// Spread files over subdirectories to keep directories small
+	return filepath.Join(c.dir, name[:2], name+".json"), nil
+}
+
+// Information about a single cache file
+type fileInfo struct {
+	path    string
+	size    int64
+	modTime time.Time
+}
+
+// Helper function to list all cache files.
+// The caller must hold c.mu.
+func (c *Cache) files() ([]fileInfo, error) {
+	var files []fileInfo
+
+	err := filepath.WalkDir(c.dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil // Nothing cached yet
+			}
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) != ".json" {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		files = append(files, fileInfo{path: path, size: info.Size(), modTime: info.ModTime()})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not read cache directory: %v", err)
+	}
+
+	return files, nil
+}
+
+// Helper function to check whether the limits have not been checked for pruneInterval.
+// Marks them as checked if so. The caller must hold c.mu.
+func (c *Cache) pruneDue() bool {
+	marker := filepath.Join(c.dir, pruneMarker)
+
+	info, err := os.Stat(marker)
+	if err == nil && time.Since(info.ModTime()) < pruneInterval {
+		return false
+	}
+
+	now := time.Now()
+	if err := os.Chtimes(marker, now, now); errors.Is(err, fs.ErrNotExist) {
+		os.WriteFile(marker, nil, 0o600)
+	}
+
+	return true
+}
+
+// Helper function to remove expired entries and, if the cache is too large,
+// the oldest entries. The caller must hold c.mu.
+func (c *Cache) prune() error {
+	files, err := c.files()
+	if err != nil {
+		return err
+	}
+
+	// Oldest first
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].modTime.Before(files[j].modTime)
+	})
+
+	var total int64
+	for _, f := range files {
+		total += f.size
+	}
+
+	for _, f := range files {
+		if !c.expired(f.modTime) && (c.maxSize <= 0 || total <= c.maxSize) {
+			break
+		}
+
+		if err := os.Remove(f.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("could not remove cache entry: %v", err)
+		}
+		total -= f.size
+	}
+
+	return nil
+}
+
+// Helper function to replace a file at once via a temporary file in the same directory
+func writeFile(path string, data []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(path), "*.tmp")
+	if err != nil {
+		return err
+	}
+	tmp := f.Name()
+
+	_, err = f.Write(data)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp, path)
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+
+	return nil
+}
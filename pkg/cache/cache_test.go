@@ -0,0 +1,149 @@
+package cache
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	deeplapi "github.com/leschuster/deepl-cli/pkg/deepl-api"
+)
+
+// Helper function to get a translate function that counts its calls
+func counting(text string, calls *int) TranslateFunc {
+	return func(ctx context.Context, params deeplapi.TranslateParams) (*deeplapi.TranslateResp, error) {
+		*calls++
+		return &deeplapi.TranslateResp{Translations: []deeplapi.Translation{{Text: text}}}, nil
+	}
+}
+
+func params(text string) deeplapi.TranslateParams {
+	return deeplapi.TranslateParams{Text: []string{text}, TargetLang: "DE"}
+}
+
+func TestTranslateCachesResult(t *testing.T) {
+	c := New(t.TempDir(), DefaultTTL, DefaultMaxSize)
+	calls := 0
+
+	for range 2 {
+		resp, err := c.Translate(context.Background(), "https://api.deepl.com/v2", params("Hello"), counting("Hallo", &calls))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := resp.Translations[0].Text; got != "Hallo" {
+			t.Errorf("got %q, want %q", got, "Hallo")
+		}
+	}
+
+	if calls != 1 {
+		t.Errorf("translated %d times, want 1", calls)
+	}
+}
+
+func TestEndpointsAreKeptApart(t *testing.T) {
+	c := New(t.TempDir(), DefaultTTL, DefaultMaxSize)
+	calls := 0
+
+	if _, err := c.Translate(context.Background(), "http://localhost:8080/v2", params("Hello"), counting("[DE] Hello", &calls)); err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := c.Translate(context.Background(), "https://api.deepl.com/v2", params("Hello"), counting("Hallo", &calls))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := resp.Translations[0].Text; got != "Hallo" {
+		t.Errorf("got %q from another endpoint, want %q", got, "Hallo")
+	}
+	if calls != 2 {
+		t.Errorf("translated %d times, want 2", calls)
+	}
+}
+
+func TestExpiredEntriesAreIgnored(t *testing.T) {
+	c := New(t.TempDir(), time.Millisecond, DefaultMaxSize)
+	resp := &deeplapi.TranslateResp{Translations: []deeplapi.Translation{{Text: "Hallo"}}}
+
+	if err := c.Put("", params("Hello"), resp); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(5 * time.Millisecond)
+
+	if _, ok := c.Get("", params("Hello")); ok {
+		t.Error("expected expired entry to be ignored")
+	}
+}
+
+func TestPruneIsThrottled(t *testing.T) {
+	dir := t.TempDir()
+	resp := &deeplapi.TranslateResp{Translations: []deeplapi.Translation{{Text: strings.Repeat("a", 1000)}}}
+
+	// Large enough for a single entry only
+	c := New(dir, DefaultTTL, 1500)
+
+	for _, text := range []string{"one", "two", "three"} {
+		if err := c.Put("", params(text), resp); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The limits were checked on the first Put only
+	if stats, _ := c.Stats(); stats.Entries != 3 {
+		t.Fatalf("got %d entries, want 3 before the next check", stats.Entries)
+	}
+
+	// Pretend the last check was long ago
+	old := time.Now().Add(-2 * pruneInterval)
+	if err := os.Chtimes(filepath.Join(dir, pruneMarker), old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.Put("", params("four"), resp); err != nil {
+		t.Fatal(err)
+	}
+
+	stats, _ := c.Stats()
+	if stats.Entries != 1 {
+		t.Fatalf("got %d entries, want 1 after the check", stats.Entries)
+	}
+	if _, ok := c.Get("", params("four")); !ok {
+		t.Error("expected the newest entry to be kept")
+	}
+}
+
+func TestConcurrentWritersOfTheSameEntry(t *testing.T) {
+	dir := t.TempDir()
+	resp := &deeplapi.TranslateResp{Translations: []deeplapi.Translation{{Text: strings.Repeat("Hallo ", 10000)}}}
+
+	// Each cache stands for another process, so they do not share a lock
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- New(dir, DefaultTTL, DefaultMaxSize).Put("https://api.deepl.com/v2", params("Hello"), resp)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Error(err)
+		}
+	}
+
+	got, ok := New(dir, DefaultTTL, DefaultMaxSize).Get("https://api.deepl.com/v2", params("Hello"))
+	if !ok || got.Translations[0].Text != resp.Translations[0].Text {
+		t.Error("expected the complete entry to be cached")
+	}
+
+	tmps, _ := filepath.Glob(filepath.Join(dir, "*", "*.tmp"))
+	if len(tmps) > 0 {
+		t.Errorf("got temporary files %v, want none to be left", tmps)
+	}
+}
@@ -0,0 +1,454 @@
+// Package config provides the user's settings, e.g. default languages.
+// Settings are stored in a TOML file.
+
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	deeplapi "github.com/leschuster/deepl-cli/pkg/deepl-api"
+)
+
+// Name of the profile that always exists, equals auth.DefaultProfile
+const defaultProfile = "default"
+
+// Config holds all settings. The zero value uses the defaults of the application.
+type Config struct {
+	SourceLang    string              `toml:"source_lang,omitempty"`    // Detected automatically if empty
+	TargetLang    string              `toml:"target_lang,omitempty"`    // Has to be selected in the UI if empty
+	Formality     string              `toml:"formality,omitempty"`      // One of deeplapi.Formality*
+	LiveTranslate bool                `toml:"live_translate,omitempty"` // Translate while typing
+	KeyMap        string              `toml:"keymap,omitempty"`         // Preset of keybindings, default or emacs
+	Profile       string              `toml:"profile,omitempty"`        // Profile whose API key is used, see auth.DefaultProfile if empty
+	Profiles      []string            `toml:"profiles,omitempty"`       // Names of all profiles besides the default profile
+	Theme         Theme               `toml:"theme,omitempty"`
+	API           API                 `toml:"api,omitempty"`
+	Cache         Cache               `toml:"cache,omitempty"`
+	Keys          map[string][]string `toml:"keys,omitempty"` // Keys per keybinding name, e.g. swap = ["s"]
+}
+
+// Theme holds settings of the look of the user interface
+type Theme struct {
+	Preset string            `toml:"preset,omitempty"` // Name of the theme to start from, default if empty
+	Border string            `toml:"border,omitempty"` // Border of views, e.g. rounded
+	Colors map[string]string `toml:"colors,omitempty"` // Colors per name, e.g. primary_background = "56"
+}
+
+// API holds settings of the connection to DeepL
+type API struct {
+	URL       string   `toml:"url,omitempty"`        // Base URL, derived from the API key if empty
+	Proxy     string   `toml:"proxy,omitempty"`      // Proxy for all requests, taken from HTTPS_PROXY if empty
+	Timeout   Duration `toml:"timeout,omitzero"`     // Upper bound per request, default timeout if zero
+	UserAgent string   `toml:"user_agent,omitempty"` // User-Agent header of all requests, optional
+}
+
+// Cache holds settings of the translation cache
+type Cache struct {
+	Disabled  bool     `toml:"disabled,omitempty"`
+	Dir       string   `toml:"dir,omitempty"`        // Default directory if empty
+	TTL       Duration `toml:"ttl,omitzero"`         // Default TTL if zero
+	MaxSizeMB int64    `toml:"max_size_mb,omitzero"` // Default size if zero
+}
+
+// Duration is a time.Duration written as string, e.g. "720h"
+type Duration time.Duration
+
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(time.Duration(d).String()), nil
+}
+
+func (d *Duration) UnmarshalText(text []byte) error {
+	v, err := time.ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+// Names of all settings that can be read and written with Get and Set.
+// Keybindings are addressed as "keys.<name>", colors as "theme.colors.<name>".
+var Settings = []string{
+	"source_lang",
+	"target_lang",
+	"formality",
+	"live_translate",
+	"keymap",
+	"theme.preset",
+	"theme.border",
+	"api.url",
+	"api.proxy",
+	"api.timeout",
+	"api.user_agent",
+	"cache.disabled",
+	"cache.dir",
+	"cache.ttl",
+	"cache.max_size_mb",
+}
+
+// DefaultPath returns the file the config is stored in by default,
+// e.g. $XDG_CONFIG_HOME/deepl-cli/config.toml on Linux
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("could not determine config directory: %v", err)
+	}
+
+	return filepath.Join(dir, "deepl-cli", "config.toml"), nil
+}
+
+// Load the config from the file at path.
+// A missing file results in the default config.
+func Load(path string) (Config, error) {
+	cfg := Config{}
+
+	md, err := toml.DecodeFile(path, &cfg)
+	if errors.Is(err, fs.ErrNotExist) {
+		return Config{}, nil
+	}
+	if err != nil {
+		return Config{}, fmt.Errorf("could not read config %s: %v", path, err)
+	}
+
+	if undecoded := md.Undecoded(); len(undecoded) > 0 {
+		return Config{}, fmt.Errorf("unknown setting '%s' in %s", undecoded[0], path)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return Config{}, fmt.Errorf("invalid config %s: %v", path, err)
+	}
+
+	return cfg, nil
+}
+
+// Save the config to the file at path
+func (c Config) Save(path string) error {
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(c); err != nil {
+		return fmt.Errorf("could not encode config: %v", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("could not create config directory: %v", err)
+	}
+
+	// Write to a temporary file first so that the config is never lost halfway
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0o600); err != nil {
+		return fmt.Errorf("could not write config: %v", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("could not write config: %v", err)
+	}
+
+	return nil
+}
+
+// Check whether all settings have valid values
+func (c Config) Validate() error {
+	formalities := []string{
+		"",
+		deeplapi.FormalityDefault,
+		deeplapi.FormalityMore,
+		deeplapi.FormalityLess,
+		deeplapi.FormalityPreferMore,
+		deeplapi.FormalityPreferLess,
+	}
+	if !slices.Contains(formalities, c.Formality) {
+		return fmt.Errorf("formality must be one of %s", strings.Join(formalities[1:], ", "))
+	}
+
+	if err := validateURL(c.API.URL, "http", "https"); err != nil {
+		return fmt.Errorf("api.url %v", err)
+	}
+
+	if err := validateURL(c.API.Proxy, "http", "https", "socks5"); err != nil {
+		return fmt.Errorf("api.proxy %v", err)
+	}
+
+	if c.API.Timeout < 0 {
+		return errors.New("api.timeout must not be negative")
+	}
+
+	if c.Cache.TTL < 0 {
+		return errors.New("cache.ttl must not be negative")
+	}
+
+	if c.Cache.MaxSizeMB < 0 {
+		return errors.New("cache.max_size_mb must not be negative")
+	}
+
+	for name, keys := range c.Keys {
+		if len(keys) == 0 {
+			return fmt.Errorf("keys.%s must contain at least one key", name)
+		}
+	}
+
+	return nil
+}
+
+// Get the value of a setting as string.
+// Keybindings are returned as comma-separated list.
+func (c Config) Get(name string) (string, error) {
+	if binding, ok := strings.CutPrefix(name, "keys."); ok {
+		return strings.Join(c.Keys[binding], ","), nil
+	}
+	if color, ok := strings.CutPrefix(name, "theme.colors."); ok {
+		return c.Theme.Colors[color], nil
+	}
+
+	switch name {
+	case "source_lang":
+		return c.SourceLang, nil
+	case "target_lang":
+		return c.TargetLang, nil
+	case "formality":
+		return c.Formality, nil
+	case "live_translate":
+		return strconv.FormatBool(c.LiveTranslate), nil
+	case "keymap":
+		return c.KeyMap, nil
+	case "theme.preset":
+		return c.Theme.Preset, nil
+	case "theme.border":
+		return c.Theme.Border, nil
+	case "api.url":
+		return c.API.URL, nil
+	case "api.proxy":
+		return c.API.Proxy, nil
+	case "api.timeout":
+		if c.API.Timeout == 0 {
+			return "", nil
+		}
+		return time.Duration(c.API.Timeout).String(), nil
+	case "api.user_agent":
+		return c.API.UserAgent, nil
+	case "cache.disabled":
+		return strconv.FormatBool(c.Cache.Disabled), nil
+	case "cache.dir":
+		return c.Cache.Dir, nil
+	case "cache.ttl":
+		if c.Cache.TTL == 0 {
+			return "", nil
+		}
+		return time.Duration(c.Cache.TTL).String(), nil
+	case "cache.max_size_mb":
+		if c.Cache.MaxSizeMB == 0 {
+			return "", nil
+		}
+		return strconv.FormatInt(c.Cache.MaxSizeMB, 10), nil
+	}
+
+	return "", fmt.Errorf("unknown setting '%s'", name)
+}
+
+// Set the value of a setting from a string.
+// An empty value restores the default. Keybindings are given as comma-separated list.
+func (c *Config) Set(name, value string) error {
+	value = strings.TrimSpace(value)
+
+	if binding, ok := strings.CutPrefix(name, "keys."); ok {
+		if binding == "" {
+			return fmt.Errorf("unknown setting '%s'", name)
+		}
+		if value == "" {
+			delete(c.Keys, binding)
+			return nil
+		}
+		if c.Keys == nil {
+			c.Keys = map[string][]string{}
+		}
+		c.Keys[binding] = SplitList(value)
+		return nil
+	}
+
+	if color, ok := strings.CutPrefix(name, "theme.colors."); ok {
+		if color == "" {
+			return fmt.Errorf("unknown setting '%s'", name)
+		}
+		if value == "" {
+			delete(c.Theme.Colors, color)
+			return nil
+		}
+		if c.Theme.Colors == nil {
+			c.Theme.Colors = map[string]string{}
+		}
+		c.Theme.Colors[color] = value
+		return nil
+	}
+
+	var err error
+
+	switch name {
+	case "source_lang":
+		c.SourceLang = strings.ToUpper(value)
+	case "target_lang":
+		c.TargetLang = strings.ToUpper(value)
+	case "formality":
+		c.Formality = strings.ToLower(value)
+	case "live_translate":
+		c.LiveTranslate, err = parseBool(value)
+	case "keymap":
+		c.KeyMap = strings.ToLower(value)
+	case "theme.preset":
+		c.Theme.Preset = strings.ToLower(value)
+	case "theme.border":
+		c.Theme.Border = strings.ToLower(value)
+	case "api.url":
+		c.API.URL = value
+	case "api.proxy":
+		c.API.Proxy = value
+	case "api.timeout":
+		c.API.Timeout = 0
+		if value != "" {
+			err = c.API.Timeout.UnmarshalText([]byte(value))
+		}
+	case "api.user_agent":
+		c.API.UserAgent = value
+	case "cache.disabled":
+		c.Cache.Disabled, err = parseBool(value)
+	case "cache.dir":
+		c.Cache.Dir = value
+	case "cache.ttl":
+		c.Cache.TTL = 0
+		if value != "" {
+			err = c.Cache.TTL.UnmarshalText([]byte(value))
+		}
+	case "cache.max_size_mb":
+		c.Cache.MaxSizeMB = 0
+		if value != "" {
+			c.Cache.MaxSizeMB, err = strconv.ParseInt(value, 10, 64)
+		}
+	default:
+		return fmt.Errorf("unknown setting '%s'", name)
+	}
+
+	if err != nil {
+		return fmt.Errorf("invalid value for %s: %v", name, err)
+	}
+
+	return c.Validate()
+}
+
+// Get the names of all settings that are currently set, including keybindings
+func (c Config) Names() []string {
+	names := slices.Clone(Settings)
+
+	var colors []string
+	for name := range c.Theme.Colors {
+		colors = append(colors, "theme.colors."+name)
+	}
+	sort.Strings(colors)
+
+	var bindings []string
+	for name := range c.Keys {
+		bindings = append(bindings, "keys."+name)
+	}
+	sort.Strings(bindings)
+
+	return slices.Concat(names, colors, bindings)
+}
+
+// Get the options for clients of the DeepL API
+func (c Config) APIOptions() []deeplapi.Option {
+	var opts []deeplapi.Option
+
+	if c.API.URL != "" {
+		opts = append(opts, deeplapi.WithBaseURL(c.API.URL))
+	}
+	if proxy, err := url.Parse(c.API.Proxy); c.API.Proxy != "" && err == nil {
+		opts = append(opts, deeplapi.WithProxy(proxy))
+	}
+	if c.API.Timeout > 0 {
+		opts = append(opts, deeplapi.WithTimeout(time.Duration(c.API.Timeout)))
+	}
+	if c.API.UserAgent != "" {
+		opts = append(opts, deeplapi.WithUserAgent(c.API.UserAgent))
+	}
+
+	return opts
+}
+
+// Helper function to check that a URL is absolute and uses one of the given schemes.
+// An empty URL is valid.
+func validateURL(rawURL string, schemes ...string) error {
+	if rawURL == "" {
+		return nil
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("is not a valid URL: %v", err)
+	}
+	if !slices.Contains(schemes, u.Scheme) || u.Host == "" {
+		return fmt.Errorf("must be a URL like %s://host", schemes[0])
+	}
+
+	return nil
+}
+
+// Helper function to parse a boolean, an empty value is false
+func parseBool(value string) (bool, error) {
+	if value == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(value)
+}
+
+// SplitList splits a comma-separated list, ignoring empty items
+func SplitList(s string) []string {
+	var res []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			res = append(res, item)
+		}
+	}
+	return res
+}
+
+// Get the names of all profiles, the default profile first
+func (c Config) AllProfiles() []string {
+	return append([]string{defaultProfile}, c.Profiles...)
+}
+
+// Check whether a profile exists
+func (c Config) HasProfile(name string) bool {
+	return slices.Contains(c.AllProfiles(), name)
+}
+
+// Get the name of the active profile
+func (c Config) ActiveProfile() string {
+	if c.Profile == "" {
+		return defaultProfile
+	}
+	return c.Profile
+}
+
+// Add a profile if it does not exist yet
+func (c *Config) AddProfile(name string) {
+	if !c.HasProfile(name) {
+		c.Profiles = append(c.Profiles, name)
+	}
+}
+
+// Remove a profile. The default profile becomes active if it was the active one.
+// The default profile cannot be removed.
+func (c *Config) RemoveProfile(name string) {
+	c.Profiles = slices.DeleteFunc(c.Profiles, func(p string) bool { return p == name })
+	if c.Profile == name {
+		c.Profile = ""
+	}
+}
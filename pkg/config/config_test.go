@@ -0,0 +1,117 @@
+package config
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestSetAndGet(t *testing.T) {
+	tests := []struct {
+		name, value, want string
+	}{
+		{"source_lang", "de", "DE"},
+		{"formality", "More", "more"},
+		{"live_translate", "true", "true"},
+		{"api.url", "https://api.deepl.com/v2", "https://api.deepl.com/v2"},
+		{"api.timeout", "30s", "30s"},
+		{"cache.ttl", "24h", "24h0m0s"},
+		{"cache.max_size_mb", "10", "10"},
+		{"keys.swap", "ctrl+s, ,s", "ctrl+s,s"},
+		{"theme.colors.primary_background", "#5f00d7", "#5f00d7"},
+	}
+
+	for _, tt := range tests {
+		c := Config{}
+		if err := c.Set(tt.name, tt.value); err != nil {
+			t.Errorf("Set(%s, %s): %v", tt.name, tt.value, err)
+			continue
+		}
+
+		got, err := c.Get(tt.name)
+		if err != nil || got != tt.want {
+			t.Errorf("Get(%s) = %q, %v, want %q", tt.name, got, err, tt.want)
+		}
+	}
+}
+
+func TestSetEmptyRestoresDefault(t *testing.T) {
+	c := Config{}
+	c.Set("cache.ttl", "24h")
+	c.Set("keys.swap", "ctrl+s")
+
+	for _, name := range []string{"cache.ttl", "keys.swap"} {
+		if err := c.Set(name, ""); err != nil {
+			t.Fatal(err)
+		}
+		if got, _ := c.Get(name); got != "" {
+			t.Errorf("Get(%s) = %q after reset, want empty", name, got)
+		}
+	}
+}
+
+func TestSetRejectsInvalidValues(t *testing.T) {
+	tests := map[string]string{
+		"formality":      "very",
+		"live_translate": "maybe",
+		"api.url":        "api.deepl.com",
+		"api.proxy":      "ftp://proxy",
+		"api.timeout":    "-1s",
+		"cache.ttl":      "soon",
+		"unknown":        "value",
+		"keys.":          "s",
+	}
+
+	for name, value := range tests {
+		c := Config{}
+		if err := c.Set(name, value); err == nil {
+			t.Errorf("Set(%s, %s) succeeded, want an error", name, value)
+		}
+	}
+}
+
+func TestSaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+
+	c := Config{}
+	c.Set("target_lang", "EN-GB")
+	c.Set("cache.max_size_mb", "10")
+	c.Set("keys.copy", "y,ctrl+y")
+	c.AddProfile("team")
+
+	if err := c.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"target_lang", "cache.max_size_mb", "keys.copy"} {
+		want, _ := c.Get(name)
+		if got, _ := loaded.Get(name); got != want {
+			t.Errorf("%s = %q after loading, want %q", name, got, want)
+		}
+	}
+	if !loaded.HasProfile("team") {
+		t.Error("expected profile team after loading")
+	}
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	c, err := Load(filepath.Join(t.TempDir(), "missing.toml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.ActiveProfile() != defaultProfile {
+		t.Errorf("got active profile %q, want %q", c.ActiveProfile(), defaultProfile)
+	}
+}
+
+func TestSplitList(t *testing.T) {
+	got := SplitList(" a, ,b,")
+	if want := []string{"a", "b"}; !slices.Equal(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
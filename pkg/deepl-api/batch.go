@@ -0,0 +1,304 @@
+package deeplapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Limits DeepL imposes on a single translation request
+const (
+	maxTextsPerRequest = 50
+	maxRequestSize     = 128 * 1024 // in bytes, including all parameters
+	requestSizeSlack   = 1024       // reserved for headers and encoding differences
+)
+
+// Default number of requests DeeplAPI.TranslateBatch sends at the same time
+const DefaultBatchConcurrency = 4
+
+// Separators to split oversized texts at, from the most to the least preferable
+var splitLevels = []*regexp.Regexp{
+	regexp.MustCompile(`\n[ \t]*\n\s*`),             // Paragraphs
+	regexp.MustCompile(`\n\s*`),                     // Lines
+	regexp.MustCompile(`(?:[.!?:;]+\s+|[。！？]+\s*)`), // Sentences, CJK ones are not followed by a space
+	regexp.MustCompile(`\s+`),                       // Words
+}
+
+// A segment is a part of an original text that fits into a single request.
+// The separator that followed it in the original text is kept to reassemble the translation.
+type segment struct {
+	text string
+	sep  string
+}
+
+// BatchTranslator is a Translator that splits texts exceeding DeepL's limits,
+// see TranslateBatch
+type BatchTranslator struct {
+	*DeeplAPI
+	Concurrency int // Maximum number of requests at the same time, DefaultBatchConcurrency if 0
+}
+
+// Make sure that BatchTranslator implements Translator
+var _ Translator = BatchTranslator{}
+
+// TranslateCtx translates params.Text with TranslateBatchCtx
+func (t BatchTranslator) TranslateCtx(ctx context.Context, params TranslateParams) (*TranslateResp, error) {
+	return t.TranslateBatchCtx(ctx, params, t.Concurrency)
+}
+
+// The TranslateBatch function works like Translate, but does not care about
+// DeepL's limits. Oversized inputs are split into compliant requests at paragraph
+// or sentence boundaries, sent with at most concurrency requests at the same time
+// and reassembled in order. A concurrency of 0 uses DefaultBatchConcurrency.
+// Beware that splitting does not know about tags, so keep single HTML/XML
+// elements below the request size limit.
+func (api *DeeplAPI) TranslateBatch(params TranslateParams, concurrency int) (*TranslateResp, error) {
+	return api.TranslateBatchCtx(context.Background(), params, concurrency)
+}
+
+// TranslateBatchCtx is like TranslateBatch, but aborts once ctx is done
+func (api *DeeplAPI) TranslateBatchCtx(ctx context.Context, params TranslateParams, concurrency int) (*TranslateResp, error) {
+	if concurrency <= 0 {
+		concurrency = DefaultBatchConcurrency
+	}
+
+	// Determine how much space is left for the texts in a single request
+	withoutText := params
+	withoutText.Text = nil
+	overhead, err := json.Marshal(withoutText)
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal options to JSON: %v", err)
+	}
+	budget := maxRequestSize - len(overhead) - requestSizeSlack
+	if budget <= 0 {
+		return nil, fmt.Errorf("context is too large to fit into a request")
+	}
+
+	// Split each text into segments, remembering which text they belong to
+	var segments []segment
+	var owners []int
+	for i, text := range params.Text {
+		for _, seg := range splitText(text, budget-3) { // quotes and comma
+			segments = append(segments, seg)
+			owners = append(owners, i)
+		}
+	}
+
+	batches := packSegments(segments, budget)
+	translated := make([]Translation, len(segments))
+
+	// Send requests with bounded concurrency
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	var once sync.Once
+	var firstErr error
+	sem := make(chan struct{}, concurrency)
+
+	for _, b := range batches {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(b batch) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			p := params
+			p.Text = make([]string, b.end-b.start)
+			for i := b.start; i < b.end; i++ {
+				p.Text[i-b.start] = segments[i].text
+			}
+
+			resp, err := api.TranslateCtx(ctx, p)
+			if err == nil && len(resp.Translations) != len(p.Text) {
+				err = fmt.Errorf("expected %d translations, but received %d", len(p.Text), len(resp.Translations))
+			}
+			if err != nil {
+				once.Do(func() {
+					firstErr = err
+					cancel()
+				})
+				return
+			}
+
+			copy(translated[b.start:b.end], resp.Translations)
+		}(b)
+	}
+
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Reassemble the translations of each text
+	res := &TranslateResp{
+		Translations: make([]Translation, len(params.Text)),
+	}
+	builders := make([]strings.Builder, len(params.Text))
+	for i, t := range translated {
+		owner := owners[i]
+		if builders[owner].Len() == 0 {
+			res.Translations[owner].DetectedSourceLanguage = t.DetectedSourceLanguage
+			res.Translations[owner].ModelTypeUsed = t.ModelTypeUsed
+		}
+		res.Translations[owner].BilledCharacters += t.BilledCharacters
+		builders[owner].WriteString(t.Text)
+		builders[owner].WriteString(segments[i].sep)
+	}
+	for i := range builders {
+		res.Translations[i].Text = builders[i].String()
+	}
+
+	return res, nil
+}
+
+// A batch is a range of segments that are sent in a single request
+type batch struct {
+	start, end int
+}
+
+// Helper function to group segments into requests that respect DeepL's limits
+func packSegments(segments []segment, budget int) []batch {
+	var batches []batch
+
+	curr := batch{}
+	size := 0
+
+	for i, seg := range segments {
+		segSize := encodedLen(seg.text) + 3 // quotes and comma
+
+		if curr.end > curr.start && (curr.end-curr.start >= maxTextsPerRequest || size+segSize > budget) {
+			batches = append(batches, curr)
+			curr = batch{start: i, end: i}
+			size = 0
+		}
+
+		curr.end = i + 1
+		size += segSize
+	}
+
+	if curr.end > curr.start {
+		batches = append(batches, curr)
+	}
+
+	return batches
+}
+
+// Helper function to split a text into segments whose JSON encoding fits into limit
+func splitText(text string, limit int) []segment {
+	if encodedLen(text) <= limit {
+		return []segment{{text: text}}
+	}
+
+	return splitAtLevel(text, limit, 0)
+}
+
+// Helper function to split a text at the separators of splitLevels[level].
+// Units that are still too large are split at the next level.
+func splitAtLevel(text string, limit, level int) []segment {
+	if level >= len(splitLevels) {
+		return splitHard(text, limit)
+	}
+
+	// Cut text into units, each followed by its separator
+	var units []segment
+	prev := 0
+	for _, loc := range splitLevels[level].FindAllStringIndex(text, -1) {
+		// Keep punctuation with the sentence, only whitespace becomes the separator
+		sepStart := loc[0] + len(strings.TrimRightFunc(text[loc[0]:loc[1]], unicode.IsSpace))
+		units = append(units, segment{text: text[prev:sepStart], sep: text[sepStart:loc[1]]})
+		prev = loc[1]
+	}
+	if prev < len(text) {
+		units = append(units, segment{text: text[prev:]})
+	}
+
+	// Greedily merge units into segments that fit into limit
+	var res []segment
+	var curr *segment
+	currSize := 0
+	for _, u := range units {
+		unitSize := encodedLen(u.text)
+
+		if unitSize > limit {
+			if curr != nil {
+				res = append(res, *curr)
+				curr = nil
+			}
+			parts := splitAtLevel(u.text, limit, level+1)
+			parts[len(parts)-1].sep += u.sep
+			res = append(res, parts...)
+			continue
+		}
+
+		if curr != nil {
+			if size := currSize + encodedLen(curr.sep) + unitSize; size <= limit {
+				curr.text += curr.sep + u.text
+				curr.sep = u.sep
+				currSize = size
+				continue
+			}
+			res = append(res, *curr)
+		}
+
+		curr = &segment{text: u.text, sep: u.sep}
+		currSize = unitSize
+	}
+	if curr != nil {
+		res = append(res, *curr)
+	}
+
+	return res
+}
+
+// Helper function to split a text without any separators at rune boundaries
+func splitHard(text string, limit int) []segment {
+	var res []segment
+
+	start, size := 0, 0
+	for i, r := range text {
+		runeSize := encodedLen(string(r))
+		if size+runeSize > limit && i > start {
+			res = append(res, segment{text: text[start:i]})
+			start, size = i, 0
+		}
+		size += runeSize
+	}
+	res = append(res, segment{text: text[start:]})
+
+	return res
+}
+
+// Helper function to get the length of a string once encoded as JSON, without quotes
+func encodedLen(s string) int {
+	// Most texts do not need escaping, avoid marshalling them
+	needsEscaping := false
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; c < 0x20 || c == '"' || c == '\\' || c == '<' || c == '>' || c == '&' || c >= utf8.RuneSelf {
+			needsEscaping = true
+			break
+		}
+	}
+	if !needsEscaping {
+		return len(s)
+	}
+
+	data, _ := json.Marshal(s)
+	return len(data) - 2
+}
@@ -0,0 +1,131 @@
+package deeplapi_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	deeplapi "github.com/leschuster/deepl-cli/pkg/deepl-api"
+	"github.com/leschuster/deepl-cli/pkg/deepl-api/fake"
+)
+
+func TestBatchKeepsOrderOfTexts(t *testing.T) {
+	srv := fake.NewServer()
+	defer srv.Close()
+
+	texts := make([]string, 120)
+	for i := range texts {
+		texts[i] = fmt.Sprintf("Text %d", i)
+	}
+
+	resp, err := srv.API().TranslateBatch(deeplapi.TranslateParams{Text: texts, TargetLang: "DE"}, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(resp.Translations) != len(texts) {
+		t.Fatalf("got %d translations, want %d", len(resp.Translations), len(texts))
+	}
+	for i, tr := range resp.Translations {
+		if want := fake.Translate(texts[i], "DE"); tr.Text != want {
+			t.Errorf("translation %d: got %q, want %q", i, tr.Text, want)
+		}
+	}
+
+	// At most 50 texts fit into a request
+	if got := srv.Requests("/translate"); got != 3 {
+		t.Errorf("got %d requests, want 3", got)
+	}
+}
+
+func TestBatchSplitsOversizedTexts(t *testing.T) {
+	srv := fake.NewServer(fake.WithCharacterLimit(0))
+	defer srv.Close()
+
+	// About 300 KiB, so the text needs to be split into at least three requests
+	paragraphs := make([]string, 3000)
+	for i := range paragraphs {
+		paragraphs[i] = fmt.Sprintf("Paragraph %d. %s", i, strings.Repeat("word ", 18))
+	}
+	text := strings.Join(paragraphs, "\n\n")
+
+	resp, err := srv.API().TranslateBatch(deeplapi.TranslateParams{Text: []string{"Short", text}, TargetLang: "DE"}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(resp.Translations) != 2 {
+		t.Fatalf("got %d translations, want 2", len(resp.Translations))
+	}
+	if got := resp.Translations[0].Text; got != "[DE] Short" {
+		t.Errorf("got %q, want [DE] Short", got)
+	}
+
+	// Each segment is translated on its own and the segments are reassembled in order
+	got := resp.Translations[1].Text
+	if !strings.HasPrefix(got, "[DE] Paragraph 0. ") {
+		t.Errorf("got %.30q..., want it to start with the first paragraph", got)
+	}
+	if strings.ReplaceAll(got, "[DE] ", "") != text {
+		t.Error("translation does not match the text once the prefixes are removed")
+	}
+	if segments := strings.Count(got, "[DE] "); segments < 3 {
+		t.Errorf("got %d segments, want at least 3", segments)
+	}
+
+	if got := srv.Requests("/translate"); got < 3 {
+		t.Errorf("got %d requests, want at least 3", got)
+	}
+}
+
+func TestBatchStopsAtFirstError(t *testing.T) {
+	srv := fake.NewServer()
+	defer srv.Close()
+
+	srv.Fail(fake.Failure{Path: "/translate", Status: deeplapi.StatusQuotaExceeded})
+
+	texts := make([]string, 500)
+	for i := range texts {
+		texts[i] = "Hello"
+	}
+
+	_, err := srv.API().TranslateBatch(deeplapi.TranslateParams{Text: texts, TargetLang: "DE"}, 1)
+	if !deeplapi.IsQuotaExceeded(err) {
+		t.Errorf("got %v, want status 456", err)
+	}
+	if got := srv.Requests("/translate"); got != 1 {
+		t.Errorf("got %d requests, want 1", got)
+	}
+}
+
+func TestBatchSplitsCJKAtSentences(t *testing.T) {
+	srv := fake.NewServer(fake.WithCharacterLimit(0))
+	defer srv.Close()
+
+	// About 200 KiB of sentences without spaces in between
+	var b strings.Builder
+	for i := range 8000 {
+		fmt.Fprintf(&b, "これは文%dです。", i)
+	}
+	text := b.String()
+
+	resp, err := srv.API().TranslateBatch(deeplapi.TranslateParams{Text: []string{text}, TargetLang: "DE"}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := resp.Translations[0].Text
+	if strings.ReplaceAll(got, "[DE] ", "") != text {
+		t.Fatal("translation does not match the text once the prefixes are removed")
+	}
+
+	segments := strings.Split(strings.TrimPrefix(got, "[DE] "), "[DE] ")
+	if len(segments) < 2 {
+		t.Fatalf("got %d segments, want the text to be split", len(segments))
+	}
+	for i, seg := range segments {
+		if !strings.HasSuffix(seg, "。") {
+			t.Errorf("segment %d ends with %q, want it to end with a sentence", i, seg[max(len(seg)-12, 0):])
+		}
+	}
+}
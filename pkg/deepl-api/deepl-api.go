@@ -1,16 +1,22 @@
 // Package deeplapi provides a wrapper around the official DeepL API.
-// You can translate text and retrieve available languages.
+// You can translate text and documents, manage glossaries and retrieve
+// available languages.
 // An API key is required. Both free and paid tiers are supported.
 
 package deeplapi
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"strings"
+	"time"
+
+	"github.com/leschuster/deepl-cli/pkg/deepl-api/internal/wait"
 )
 
 // Defines the formality of the translated text.
@@ -23,53 +29,128 @@ const (
 	FormalityPreferLess = "prefer_less"
 )
 
+// Defines which kind of tags the text contains.
+// Tags are not translated and their structure is kept.
+const (
+	TagHandlingNone = ""
+	TagHandlingXML  = "xml"
+	TagHandlingHTML = "html"
+)
+
+// Defines whether the text is split into sentences before translating.
+const (
+	SplitSentencesNone       = "0"          // Treat the text as a single sentence
+	SplitSentencesAll        = "1"          // Split at punctuation and newlines, the default
+	SplitSentencesNoNewlines = "nonewlines" // Split at punctuation only
+)
+
+// Defines which kind of model DeepL uses for the translation.
+const (
+	ModelTypeQualityOptimized       = "quality_optimized"
+	ModelTypePreferQualityOptimized = "prefer_quality_optimized"
+	ModelTypeLatencyOptimized       = "latency_optimized"
+)
+
 const baseURLFree = "https://api-free.deepl.com/v2"
 const baseURLPro = "https://api.deepl.com/v2"
 
+// Upper bound for a single request, including reading the response body.
+// Use WithTimeout or the *Ctx variants of the methods to define other deadlines.
+const defaultTimeout = 2 * time.Minute
+
 // DeeplAPI provides abstract access to the official DeepL API
 type DeeplAPI struct {
-	apiKey  string
-	baseURL string
-	client  *http.Client
+	apiKey    string
+	baseURL   string
+	userAgent string
+	client    *http.Client
+	retry     RetryPolicy
 }
 
-// Creates a new DeeplAPI instance
-func New(apiKey string) *DeeplAPI {
-	// Free API keys have ":fx" as suffix
-	isFreeTier := strings.HasSuffix(apiKey, ":fx")
+// Creates a new DeeplAPI instance.
+// The endpoint is derived from the API key, unless options define otherwise.
+func New(apiKey string, opts ...Option) *DeeplAPI {
+	o := options{}
+	for _, opt := range opts {
+		opt(&o)
+	}
 
-	baseURL := baseURLPro
-	if isFreeTier {
-		baseURL = baseURLFree
+	baseURL := o.baseURL
+	if baseURL == "" {
+		baseURL = baseURLPro
+		if IsFreeKey(apiKey) {
+			baseURL = baseURLFree
+		}
+	}
+
+	retry := DefaultRetryPolicy()
+	if o.retry != nil {
+		retry = *o.retry
 	}
 
 	return &DeeplAPI{
-		apiKey:  apiKey,
-		baseURL: baseURL,
-		client:  &http.Client{},
+		apiKey:    apiKey,
+		baseURL:   baseURL,
+		userAgent: o.userAgent,
+		client:    o.httpClient(),
+		retry:     retry,
 	}
 }
 
+// Check whether an API key belongs to the free tier.
+// Free API keys have ":fx" as suffix.
+func IsFreeKey(apiKey string) bool {
+	return strings.HasSuffix(strings.TrimSpace(apiKey), ":fx")
+}
+
+// Get the base URL requests are sent to, e.g. "https://api-free.deepl.com/v2"
+func (api *DeeplAPI) BaseURL() string {
+	return api.baseURL
+}
+
 // Parameters for DeeplAPI.Translate
 // Text and TargetLang are required
 type TranslateParams struct {
-	Text       []string `json:"text"`        // Text to translate, UTF-8
-	SourceLang string   `json:"source_lang"` // Original language code, optional
-	TargetLang string   `json:"target_lang"` // Target Language code
-	Context    string   `json:"context"`     // Additional context that influences the translation, but is not translated itself, optional
-	Formality  string   `json:"formality"`   // Define whether the text should be formal or more informal, not supported by all languages, optional
+	Text       []string `json:"text"`                  // Text to translate, UTF-8
+	SourceLang string   `json:"source_lang"`           // Original language code, optional
+	TargetLang string   `json:"target_lang"`           // Target Language code
+	Context    string   `json:"context"`               // Additional context that influences the translation, but is not translated itself, optional
+	Formality  string   `json:"formality"`             // Define whether the text should be formal or more informal, not supported by all languages, optional
+	GlossaryID string   `json:"glossary_id,omitempty"` // Glossary to use for the translation, requires SourceLang, optional
+
+	// The following options are all optional and only sent if they are set
+
+	TagHandling          string   `json:"tag_handling,omitempty"`           // Kind of tags the text contains, xml or html
+	OutlineDetection     *bool    `json:"outline_detection,omitempty"`      // Whether to detect the XML structure automatically, DeepL defaults to true
+	NonSplittingTags     []string `json:"non_splitting_tags,omitempty"`     // XML tags that never split sentences
+	SplittingTags        []string `json:"splitting_tags,omitempty"`         // XML tags that always split sentences
+	IgnoreTags           []string `json:"ignore_tags,omitempty"`            // XML tags whose content is not translated
+	SplitSentences       string   `json:"split_sentences,omitempty"`        // Whether to split the text into sentences, see SplitSentences*
+	PreserveFormatting   bool     `json:"preserve_formatting,omitempty"`    // Whether to keep punctuation and upper/lower case as is
+	ModelType            string   `json:"model_type,omitempty"`             // Kind of model to use, see ModelType*
+	ShowBilledCharacters bool     `json:"show_billed_characters,omitempty"` // Whether to report the billed characters per translation
 }
 
 // Response type for DeeplAPI.Translate
 type TranslateResp struct {
-	Translations []struct {
-		DetectedSourceLanguage string `json:"detected_source_language"`
-		Text                   string `json:"text"`
-	} `json:"translations"`
+	Translations []Translation `json:"translations"`
+}
+
+// Translation of a single text, part of TranslateResp
+type Translation struct {
+	DetectedSourceLanguage string `json:"detected_source_language"`
+	Text                   string `json:"text"`
+	BilledCharacters       int    `json:"billed_characters"` // Only set if ShowBilledCharacters was requested
+	ModelTypeUsed          string `json:"model_type_used"`   // Only set if a ModelType was requested
 }
 
 // The Translate function uses DeepL to translate params.Text into the specified language
 func (api *DeeplAPI) Translate(params TranslateParams) (*TranslateResp, error) {
+	return api.TranslateCtx(context.Background(), params)
+}
+
+// TranslateCtx is like Translate, but aborts once ctx is done
+func (api *DeeplAPI) TranslateCtx(ctx context.Context, params TranslateParams) (*TranslateResp, error) {
 
 	// Marshal request body
 	body, err := json.Marshal(params)
@@ -78,7 +159,7 @@ func (api *DeeplAPI) Translate(params TranslateParams) (*TranslateResp, error) {
 	}
 
 	// Make request
-	data, err := api.request("/translate", http.MethodPost, body)
+	data, err := api.request(ctx, "/translate", http.MethodPost, body)
 	if err != nil {
 		return nil, err
 	}
@@ -112,8 +193,13 @@ type GetLanguagesResp struct {
 // Because supported source and target languages may differ, the reponse differentiates
 // between them
 func (api *DeeplAPI) GetLanguages() (*GetLanguagesResp, error) {
+	return api.GetLanguagesCtx(context.Background())
+}
+
+// GetLanguagesCtx is like GetLanguages, but aborts once ctx is done
+func (api *DeeplAPI) GetLanguagesCtx(ctx context.Context) (*GetLanguagesResp, error) {
 	// Fetch source languages
-	srcLangRaw, err := api.request("/languages?type=source", http.MethodGet, nil)
+	srcLangRaw, err := api.request(ctx, "/languages?type=source", http.MethodGet, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -125,7 +211,7 @@ func (api *DeeplAPI) GetLanguages() (*GetLanguagesResp, error) {
 	}
 
 	// Fetch target languages
-	tarLangRaw, err := api.request("/languages?type=target", http.MethodGet, nil)
+	tarLangRaw, err := api.request(ctx, "/languages?type=target", http.MethodGet, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -142,33 +228,65 @@ func (api *DeeplAPI) GetLanguages() (*GetLanguagesResp, error) {
 	}, nil
 }
 
-// Helper function to perform a request to the DeepL API
-func (api *DeeplAPI) request(endpoint, method string, body []byte) ([]byte, error) {
+// Helper function to perform a JSON request to the DeepL API
+func (api *DeeplAPI) request(ctx context.Context, endpoint, method string, body []byte) ([]byte, error) {
+	return api.requestWithContentType(ctx, endpoint, method, "application/json", body)
+}
+
+// Helper function to perform a request with an arbitrary body to the DeepL API.
+// Failed requests are repeated according to the retry policy.
+func (api *DeeplAPI) requestWithContentType(ctx context.Context, endpoint, method, contentType string, body []byte) ([]byte, error) {
+	idempotent := isIdempotent(method, endpoint)
+
+	for attempt := 1; ; attempt++ {
+		data, err := api.doRequest(ctx, endpoint, method, contentType, body)
+		if ctx.Err() != nil || !api.retry.shouldRetry(attempt, err, idempotent) {
+			return data, err
+		}
+
+		var retryAfter time.Duration
+		var apiErr *APIError
+		if errors.As(err, &apiErr) {
+			retryAfter = apiErr.RetryAfter
+		}
+
+		if err := wait.For(ctx, api.retry.delay(attempt, retryAfter)); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Helper function to perform a single request to the DeepL API
+func (api *DeeplAPI) doRequest(ctx context.Context, endpoint, method, contentType string, body []byte) ([]byte, error) {
 	// Join path
 	reqURL := api.baseURL + endpoint
 
 	// Create request
-	req, err := http.NewRequest(method, reqURL, bytes.NewBuffer(body))
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, bytes.NewBuffer(body))
 	if err != nil {
 		return nil, fmt.Errorf("could not create request: %v", err)
 	}
-	req.Header.Add("Content-Type", "application/json")
+	req.Header.Add("Content-Type", contentType)
 	req.Header.Add("Authorization", fmt.Sprintf("DeepL-Auth-Key %s", api.apiKey))
+	if api.userAgent != "" {
+		req.Header.Set("User-Agent", api.userAgent)
+	}
 
 	// Perform request
 	resp, err := api.client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("request to '%s' failed", reqURL)
+		return nil, fmt.Errorf("request to '%s' failed: %w", reqURL, err)
 	}
 	defer resp.Body.Close()
-	if resp.StatusCode > 299 {
-		return nil, fmt.Errorf("request to '%s' failed with status %s", reqURL, resp.Status)
-	}
 
 	// Read response body
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("request to '%s' failed: could not read response body", reqURL)
+		return nil, fmt.Errorf("request to '%s' failed: could not read response body: %w", reqURL, err)
+	}
+
+	if resp.StatusCode > 299 {
+		return nil, newAPIError(resp, reqURL, respBody)
 	}
 
 	return respBody, nil
@@ -0,0 +1,94 @@
+package deeplapi_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	deeplapi "github.com/leschuster/deepl-cli/pkg/deepl-api"
+	"github.com/leschuster/deepl-cli/pkg/deepl-api/fake"
+)
+
+// RoundTripper that reports when the headers of a response have been received
+type notifyingTransport struct {
+	headers chan struct{}
+}
+
+func (t notifyingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := http.DefaultTransport.RoundTrip(req)
+	close(t.headers)
+	return resp, err
+}
+
+func TestCancelWhileReadingBody(t *testing.T) {
+	// Send the headers and part of the body, then stall until the client gives up
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", "100")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"translations":`))
+		w.(http.Flusher).Flush()
+
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	transport := notifyingTransport{headers: make(chan struct{})}
+	api := deeplapi.New("key",
+		deeplapi.WithBaseURL(srv.URL),
+		deeplapi.WithHTTPClient(&http.Client{Transport: transport}),
+		deeplapi.WithRetryPolicy(deeplapi.NoRetryPolicy()),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := api.TranslateCtx(ctx, deeplapi.TranslateParams{Text: []string{"Hello"}, TargetLang: "DE"})
+		done <- err
+	}()
+
+	// Cancel while the body is being read
+	<-transport.headers
+	cancel()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("got %v, want an error wrapping context.Canceled", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("request was not aborted")
+	}
+}
+
+func TestCancelBatch(t *testing.T) {
+	srv := fake.NewServer(fake.WithLatency(time.Minute))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	texts := make([]string, 200)
+	for i := range texts {
+		texts[i] = "Hello"
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := srv.API().TranslateBatchCtx(ctx, deeplapi.TranslateParams{Text: texts, TargetLang: "DE"}, 2)
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("got %v, want an error wrapping context.DeadlineExceeded", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("request was not aborted")
+	}
+}
@@ -0,0 +1,225 @@
+package deeplapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/leschuster/deepl-cli/pkg/deepl-api/internal/wait"
+)
+
+// Defines the state of a document translation.
+const (
+	DocumentStatusQueued      = "queued"
+	DocumentStatusTranslating = "translating"
+	DocumentStatusDone        = "done"
+	DocumentStatusError       = "error"
+)
+
+// Bounds for the interval in which DeeplAPI.WaitForDocument polls the status
+const (
+	documentPollMin = 1 * time.Second
+	documentPollMax = 30 * time.Second
+)
+
+// Parameters for DeeplAPI.UploadDocument
+// File, Filename and TargetLang are required
+type DocumentParams struct {
+	File         io.Reader // Content of the document (.docx, .pptx, .pdf, .html, .txt, ...)
+	Filename     string    // Name of the file, DeepL uses its extension to detect the file type
+	SourceLang   string    // Original language code, optional
+	TargetLang   string    // Target Language code
+	Formality    string    // Define whether the text should be formal or more informal, not supported by all languages, optional
+	GlossaryID   string    // Glossary to use for the translation, requires SourceLang, optional
+	OutputFormat string    // File extension of the desired output format, e.g. "docx" for a .pdf input, optional
+}
+
+// DocumentHandle identifies an uploaded document.
+// Both the ID and the key are needed to access the document later on.
+type DocumentHandle struct {
+	DocumentID  string `json:"document_id"`
+	DocumentKey string `json:"document_key"`
+}
+
+// Response type for DeeplAPI.GetDocumentStatus
+type DocumentStatus struct {
+	DocumentID       string `json:"document_id"`
+	Status           string `json:"status"`            // queued, translating, done or error
+	SecondsRemaining int    `json:"seconds_remaining"` // Estimated time until the translation is done, only while translating
+	BilledCharacters int    `json:"billed_characters"` // Number of characters billed, only once done
+	ErrorMessage     string `json:"error_message"`     // Short description of the error, only on error
+}
+
+// Whether the translation finished successfully
+func (s *DocumentStatus) Done() bool {
+	return s.Status == DocumentStatusDone
+}
+
+// Whether the translation failed
+func (s *DocumentStatus) Failed() bool {
+	return s.Status == DocumentStatusError
+}
+
+// The UploadDocument function uploads a document to DeepL and starts its translation.
+// The returned handle is needed to check the status and download the result.
+func (api *DeeplAPI) UploadDocument(params DocumentParams) (*DocumentHandle, error) {
+	return api.UploadDocumentCtx(context.Background(), params)
+}
+
+// UploadDocumentCtx is like UploadDocument, but aborts once ctx is done
+func (api *DeeplAPI) UploadDocumentCtx(ctx context.Context, params DocumentParams) (*DocumentHandle, error) {
+	if params.File == nil {
+		return nil, fmt.Errorf("no document provided")
+	}
+	if params.Filename == "" {
+		return nil, fmt.Errorf("no filename provided")
+	}
+
+	// Build multipart request body
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+
+	fields := [][2]string{
+		{"source_lang", params.SourceLang},
+		{"target_lang", params.TargetLang},
+		{"formality", params.Formality},
+		{"glossary_id", params.GlossaryID},
+		{"output_format", params.OutputFormat},
+	}
+	for _, field := range fields {
+		if field[1] == "" {
+			continue // optional field not set
+		}
+		if err := w.WriteField(field[0], field[1]); err != nil {
+			return nil, fmt.Errorf("could not write field '%s': %v", field[0], err)
+		}
+	}
+
+	part, err := w.CreateFormFile("file", params.Filename)
+	if err != nil {
+		return nil, fmt.Errorf("could not create form file: %v", err)
+	}
+	if _, err := io.Copy(part, params.File); err != nil {
+		return nil, fmt.Errorf("could not read document: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("could not finalize request body: %v", err)
+	}
+
+	// Make request
+	data, err := api.requestWithContentType(ctx, "/document", http.MethodPost, w.FormDataContentType(), buf.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal response
+	handle := DocumentHandle{}
+	err = json.Unmarshal(data, &handle)
+	if err != nil {
+		return nil, fmt.Errorf("could not unmarshall response: %v", err)
+	}
+
+	return &handle, nil
+}
+
+// The GetDocumentStatus function retrieves the current state of a document translation
+func (api *DeeplAPI) GetDocumentStatus(handle DocumentHandle) (*DocumentStatus, error) {
+	return api.GetDocumentStatusCtx(context.Background(), handle)
+}
+
+// GetDocumentStatusCtx is like GetDocumentStatus, but aborts once ctx is done
+func (api *DeeplAPI) GetDocumentStatusCtx(ctx context.Context, handle DocumentHandle) (*DocumentStatus, error) {
+	body, err := json.Marshal(map[string]string{"document_key": handle.DocumentKey})
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal options to JSON: %v", err)
+	}
+
+	data, err := api.request(ctx, "/document/"+url.PathEscape(handle.DocumentID), http.MethodPost, body)
+	if err != nil {
+		return nil, err
+	}
+
+	status := DocumentStatus{}
+	err = json.Unmarshal(data, &status)
+	if err != nil {
+		return nil, fmt.Errorf("could not unmarshall response: %v", err)
+	}
+
+	return &status, nil
+}
+
+// The DownloadDocument function retrieves the translated document.
+// DeepL only allows downloading a document once, after its status is "done".
+func (api *DeeplAPI) DownloadDocument(handle DocumentHandle) ([]byte, error) {
+	return api.DownloadDocumentCtx(context.Background(), handle)
+}
+
+// DownloadDocumentCtx is like DownloadDocument, but aborts once ctx is done
+func (api *DeeplAPI) DownloadDocumentCtx(ctx context.Context, handle DocumentHandle) ([]byte, error) {
+	body, err := json.Marshal(map[string]string{"document_key": handle.DocumentKey})
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal options to JSON: %v", err)
+	}
+
+	return api.request(ctx, "/document/"+url.PathEscape(handle.DocumentID)+"/result", http.MethodPost, body)
+}
+
+// The WaitForDocument function blocks until the translation of the document
+// either finished or failed. It uses DeepL's estimate of the remaining seconds
+// to decide when to check again.
+func (api *DeeplAPI) WaitForDocument(handle DocumentHandle) (*DocumentStatus, error) {
+	return api.WaitForDocumentCtx(context.Background(), handle)
+}
+
+// WaitForDocumentCtx is like WaitForDocument, but aborts once ctx is done
+func (api *DeeplAPI) WaitForDocumentCtx(ctx context.Context, handle DocumentHandle) (*DocumentStatus, error) {
+	for {
+		status, err := api.GetDocumentStatusCtx(ctx, handle)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case status.Done():
+			return status, nil
+		case status.Failed():
+			return status, fmt.Errorf("document translation failed: %s", status.ErrorMessage)
+		}
+
+		if err := wait.For(ctx, documentPollInterval(status.SecondsRemaining)); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// The TranslateDocument function uploads a document, waits for the translation
+// to complete and returns the translated document.
+func (api *DeeplAPI) TranslateDocument(params DocumentParams) ([]byte, error) {
+	return api.TranslateDocumentCtx(context.Background(), params)
+}
+
+// TranslateDocumentCtx is like TranslateDocument, but aborts once ctx is done
+func (api *DeeplAPI) TranslateDocumentCtx(ctx context.Context, params DocumentParams) ([]byte, error) {
+	handle, err := api.UploadDocumentCtx(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := api.WaitForDocumentCtx(ctx, *handle); err != nil {
+		return nil, err
+	}
+
+	return api.DownloadDocumentCtx(ctx, *handle)
+}
+
+// Helper function to derive the poll interval from the estimated remaining seconds
+func documentPollInterval(secondsRemaining int) time.Duration {
+	interval := time.Duration(secondsRemaining) * time.Second
+	return min(max(interval, documentPollMin), documentPollMax)
+}
@@ -0,0 +1,118 @@
+package deeplapi_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	deeplapi "github.com/leschuster/deepl-cli/pkg/deepl-api"
+	"github.com/leschuster/deepl-cli/pkg/deepl-api/fake"
+)
+
+func TestDocumentRoundTrip(t *testing.T) {
+	srv := fake.NewServer()
+	defer srv.Close()
+
+	api := srv.API()
+	params := deeplapi.DocumentParams{
+		File:       strings.NewReader("Hello world"),
+		Filename:   "hello.txt",
+		TargetLang: "FR",
+	}
+
+	handle, err := api.UploadDocument(params)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	status, err := api.WaitForDocument(*handle)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !status.Done() || status.BilledCharacters != len("Hello world") {
+		t.Errorf("got %+v, want done with %d billed characters", status, len("Hello world"))
+	}
+
+	result, err := api.DownloadDocument(*handle)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(result) != "[FR] Hello world" {
+		t.Errorf("got %q, want [FR] Hello world", result)
+	}
+
+	// Like DeepL, the result can only be downloaded once
+	if _, err := api.DownloadDocument(*handle); !hasStatus(err, 404) {
+		t.Errorf("got %v, want status 404 on the second download", err)
+	}
+}
+
+func TestTranslateDocument(t *testing.T) {
+	srv := fake.NewServer()
+	defer srv.Close()
+
+	result, err := srv.API().TranslateDocument(deeplapi.DocumentParams{
+		File:       strings.NewReader("[DE] Hallo"),
+		Filename:   "hallo.txt",
+		TargetLang: "EN-GB",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(result) != "[EN-GB] Hallo" {
+		t.Errorf("got %q, want [EN-GB] Hallo", result)
+	}
+}
+
+func TestDocumentWithWrongKey(t *testing.T) {
+	srv := fake.NewServer()
+	defer srv.Close()
+
+	api := srv.API()
+	handle, err := api.UploadDocument(deeplapi.DocumentParams{
+		File:       strings.NewReader("Hello"),
+		Filename:   "hello.txt",
+		TargetLang: "DE",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	handle.DocumentKey = "wrong"
+	if _, err := api.GetDocumentStatus(*handle); err == nil {
+		t.Error("expected an error for a wrong document key")
+	}
+}
+
+// Helper function to check whether err is an APIError with the given status code
+func hasStatus(err error, statusCode int) bool {
+	var apiErr *deeplapi.APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == statusCode
+}
+
+func TestDocumentIDIsEscaped(t *testing.T) {
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.EscapedPath())
+		w.Write([]byte(`{"status":"done"}`))
+	}))
+	defer srv.Close()
+
+	api := deeplapi.New("key", deeplapi.WithBaseURL(srv.URL))
+	handle := deeplapi.DocumentHandle{DocumentID: "a/b?c", DocumentKey: "key"}
+
+	if _, err := api.GetDocumentStatus(handle); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := api.DownloadDocument(handle); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"/document/a%2Fb%3Fc", "/document/a%2Fb%3Fc/result"}
+	if !slices.Equal(paths, want) {
+		t.Errorf("got paths %q, want %q", paths, want)
+	}
+}
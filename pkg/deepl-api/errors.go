@@ -0,0 +1,105 @@
+package deeplapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Status codes with a special meaning for the DeepL API
+const (
+	StatusForbidden       = http.StatusForbidden             // 403, the API key is invalid
+	StatusTooLarge        = http.StatusRequestEntityTooLarge // 413, the request size exceeds the limit
+	StatusTooManyRequests = http.StatusTooManyRequests       // 429, too many requests, try again later
+	StatusQuotaExceeded   = 456                              // 456, the character limit has been reached
+)
+
+// APIError is returned whenever DeepL answers a request with an error status.
+// Use errors.As to access it, or one of the Is* helpers to check for an error class.
+type APIError struct {
+	StatusCode int    // HTTP status code of the response
+	Status     string // HTTP status text of the response, e.g. "403 Forbidden"
+	URL        string // URL the request was sent to
+	Message    string // Error message provided by DeepL, may be empty
+	Detail     string // Additional information provided by DeepL, may be empty
+
+	// Time to wait before retrying as requested by DeepL, may be zero
+	RetryAfter time.Duration
+}
+
+// Get error message as string
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("request to '%s' failed with status %s", e.URL, e.Status)
+
+	if e.Message != "" {
+		msg = fmt.Sprintf("%s: %s", msg, e.Message)
+	}
+	if e.Detail != "" {
+		msg = fmt.Sprintf("%s (%s)", msg, e.Detail)
+	}
+
+	return msg
+}
+
+// Whether the API key was rejected
+func IsAuthFailed(err error) bool {
+	return hasStatus(err, StatusForbidden)
+}
+
+// Whether the request was too large to be processed
+func IsTooLarge(err error) bool {
+	return hasStatus(err, StatusTooLarge)
+}
+
+// Whether too many requests have been sent in a short amount of time
+func IsRateLimited(err error) bool {
+	return hasStatus(err, StatusTooManyRequests)
+}
+
+// Whether the character limit of the account has been reached
+func IsQuotaExceeded(err error) bool {
+	return hasStatus(err, StatusQuotaExceeded)
+}
+
+// Whether DeepL is temporarily unable to process the request
+func IsServerError(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode >= 500 && apiErr.StatusCode != StatusQuotaExceeded
+}
+
+// Helper function to check if err is an APIError with the given status code
+func hasStatus(err error, statusCode int) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == statusCode
+}
+
+// Helper function to check if err is an APIError at all
+func isAPIError(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr)
+}
+
+// Helper function to build an APIError from an error response
+func newAPIError(resp *http.Response, url string, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		URL:        url,
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+	}
+
+	// DeepL usually describes the error in a JSON body.
+	// If it does not, we stick with the status.
+	content := struct {
+		Message string `json:"message"`
+		Detail  string `json:"detail"`
+	}{}
+	if err := json.Unmarshal(body, &content); err == nil {
+		apiErr.Message = content.Message
+		apiErr.Detail = content.Detail
+	}
+
+	return apiErr
+}
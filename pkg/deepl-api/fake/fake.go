@@ -0,0 +1,282 @@
+// Package fake provides an in-process server that imitates the DeepL API.
+// It translates deterministically, works without network access and can be
+// told to answer slowly or with errors, so that clients can be tested
+// against it and the application can be developed offline.
+//
+//	srv := fake.NewServer()
+//	defer srv.Close()
+//
+//	api := srv.API()
+//	resp, err := api.Translate(deeplapi.TranslateParams{Text: []string{"Hello"}, TargetLang: "DE"})
+//	// resp.Translations[0].Text == "[DE] Hello"
+
+package fake
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	deeplapi "github.com/leschuster/deepl-cli/pkg/deepl-api"
+	"github.com/leschuster/deepl-cli/pkg/deepl-api/internal/wait"
+)
+
+// API key the fake accepts by default. It belongs to the free tier.
+const AuthKey = "fake-auth-key:fx"
+
+// Path all endpoints are served under, like the real API
+const basePath = "/v2"
+
+// Character limit per billing period by default
+const DefaultCharacterLimit = 500000
+
+// Error message DeepL sends once the character limit has been reached
+const quotaExceeded = "Quota Exceeded"
+
+// Option changes the initial behavior of a Handler
+type Option func(*Handler)
+
+// Accept this API key instead of AuthKey
+func WithAuthKey(apiKey string) Option {
+	return func(h *Handler) {
+		h.authKey = apiKey
+	}
+}
+
+// Delay every response by the given duration, see Handler.SetLatency
+func WithLatency(latency time.Duration) Option {
+	return func(h *Handler) {
+		h.latency = latency
+	}
+}
+
+// Set the number of characters that can be translated, zero for no limit.
+// Requests exceeding the limit fail with status 456.
+func WithCharacterLimit(limit int64) Option {
+	return func(h *Handler) {
+		h.usage.CharacterLimit = limit
+	}
+}
+
+// Failure makes requests fail with an error status instead of being processed
+type Failure struct {
+	Path       string        // Only requests to this endpoint fail, e.g. "/translate", all requests if empty
+	Status     int           // Status code to respond with, e.g. 429, 456 or 503
+	Times      int           // Number of requests that fail, every request if zero
+	RetryAfter time.Duration // Sent as Retry-After header if set
+	Message    string        // Error message in the response body, optional
+}
+
+// Handler implements the endpoints of the DeepL API under /v2.
+// It is safe for concurrent use.
+type Handler struct {
+	mux *http.ServeMux
+
+	mu         sync.Mutex
+	authKey    string
+	latency    time.Duration
+	failures   []*Failure
+	requests   map[string]int
+	usage      deeplapi.Usage
+	glossaries map[string]*glossary
+	documents  map[string]*document
+	nextID     int
+}
+
+// Create a new handler, e.g. to serve the fake on a fixed address
+func NewHandler(opts ...Option) *Handler {
+	h := &Handler{
+		mux:        http.NewServeMux(),
+		authKey:    AuthKey,
+		requests:   map[string]int{},
+		usage:      deeplapi.Usage{CharacterLimit: DefaultCharacterLimit},
+		glossaries: map[string]*glossary{},
+		documents:  map[string]*document{},
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	h.routes()
+
+	return h
+}
+
+// Delay every response by the given duration.
+// Requests that are aborted by the client return early.
+func (h *Handler) SetLatency(latency time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.latency = latency
+}
+
+// Make requests fail, see Failure.
+// Failures are checked in the order they were added.
+func (h *Handler) Fail(f Failure) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.failures = append(h.failures, &f)
+}
+
+// Remove all failures that have not been used up yet
+func (h *Handler) ClearFailures() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.failures = nil
+}
+
+// Get the number of requests received for an endpoint, e.g. "/translate",
+// including failed ones. An empty endpoint counts all requests.
+func (h *Handler) Requests(endpoint string) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if endpoint == "" {
+		total := 0
+		for _, n := range h.requests {
+			total += n
+		}
+		return total
+	}
+
+	return h.requests[endpoint]
+}
+
+// Get the characters translated so far and the limit
+func (h *Handler) Usage() deeplapi.Usage {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.usage
+}
+
+// Handle a request to the API
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	endpoint := endpointOf(r.URL.Path)
+
+	h.mu.Lock()
+	h.requests[endpoint]++
+	latency := h.latency
+	authKey := h.authKey
+	h.mu.Unlock()
+
+	// Read the body first, the server only notices aborted requests once it has been read
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Could not read request body")
+		return
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	if err := wait.For(r.Context(), latency); err != nil {
+		return // The client is gone
+	}
+
+	if r.Header.Get("Authorization") != "DeepL-Auth-Key "+authKey {
+		writeError(w, http.StatusForbidden, "Wrong authentication key")
+		return
+	}
+
+	if f, ok := h.takeFailure(endpoint); ok {
+		if f.RetryAfter > 0 {
+			seconds := int((f.RetryAfter + time.Second - 1) / time.Second) // Rounded up
+			w.Header().Set("Retry-After", strconv.Itoa(seconds))
+		}
+		msg := f.Message
+		if msg == "" {
+			msg = statusText(f.Status)
+		}
+		writeError(w, f.Status, msg)
+		return
+	}
+
+	h.mux.ServeHTTP(w, r)
+}
+
+// Helper function to get the failure that applies to a request, if any
+func (h *Handler) takeFailure(endpoint string) (Failure, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for i, f := range h.failures {
+		if f.Path != "" && f.Path != endpoint {
+			continue
+		}
+
+		if f.Times > 0 {
+			f.Times--
+			if f.Times == 0 {
+				h.failures = append(h.failures[:i], h.failures[i+1:]...)
+			}
+		}
+
+		return *f, true
+	}
+
+	return Failure{}, false
+}
+
+// Server is a Handler that listens on a random local port
+type Server struct {
+	*Handler
+	URL string // Base URL of the API, e.g. "http://127.0.0.1:41234/v2"
+
+	srv *httptest.Server
+}
+
+// Start a new server. Call Close once it is not needed anymore.
+func NewServer(opts ...Option) *Server {
+	h := NewHandler(opts...)
+	srv := httptest.NewServer(h)
+
+	return &Server{
+		Handler: h,
+		URL:     srv.URL + basePath,
+		srv:     srv,
+	}
+}
+
+// Shut down the server
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// Create a client that sends its requests to the server
+func (s *Server) API(opts ...deeplapi.Option) *deeplapi.DeeplAPI {
+	s.mu.Lock()
+	authKey := s.authKey
+	s.mu.Unlock()
+
+	opts = append([]deeplapi.Option{deeplapi.WithBaseURL(s.URL)}, opts...)
+	return deeplapi.New(authKey, opts...)
+}
+
+// Helper function to get the error message DeepL sends for a status code.
+// Status 456 is specific to DeepL, so net/http does not know it.
+func statusText(status int) string {
+	if status == deeplapi.StatusQuotaExceeded {
+		return quotaExceeded
+	}
+	return http.StatusText(status)
+}
+
+// Helper function to get the endpoint of a request path, e.g. "/glossaries/{id}"
+func endpointOf(path string) string {
+	path = strings.TrimPrefix(path, basePath)
+
+	parts := strings.Split(path, "/")
+	if len(parts) > 2 && (parts[1] == "glossaries" || parts[1] == "document") {
+		parts[2] = "{id}"
+	}
+
+	return strings.Join(parts, "/")
+}
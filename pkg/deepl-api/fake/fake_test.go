@@ -0,0 +1,157 @@
+package fake
+
+import (
+	"errors"
+	"testing"
+
+	deeplapi "github.com/leschuster/deepl-cli/pkg/deepl-api"
+)
+
+// Helper function to translate a single text with the fake
+func translate(api *deeplapi.DeeplAPI, text, targetLang string) (*deeplapi.TranslateResp, error) {
+	return api.Translate(deeplapi.TranslateParams{Text: []string{text}, TargetLang: targetLang})
+}
+
+func TestTranslate(t *testing.T) {
+	tests := []struct {
+		text, targetLang, want string
+	}{
+		{"Hello", "de", "[DE] Hello"},
+		{"[DE] Hello", "EN-GB", "[EN-GB] Hello"},
+		{"[XX] Hello", "DE", "[DE] [XX] Hello"},
+		{"", "DE", ""},
+	}
+
+	for _, tt := range tests {
+		if got := Translate(tt.text, tt.targetLang); got != tt.want {
+			t.Errorf("Translate(%q, %q) = %q, want %q", tt.text, tt.targetLang, got, tt.want)
+		}
+	}
+}
+
+func TestTranslateDetectsSourceLanguage(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	resp, err := translate(srv.API(), "[DE] Hallo", "EN-US")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := resp.Translations[0]
+	if got.Text != "[EN-US] Hallo" || got.DetectedSourceLanguage != "DE" {
+		t.Errorf("got %+v, want [EN-US] Hallo detected as DE", got)
+	}
+}
+
+func TestWrongAuthKey(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	api := deeplapi.New("wrong-key:fx", deeplapi.WithBaseURL(srv.URL))
+	if _, err := translate(api, "Hello", "DE"); !deeplapi.IsAuthFailed(err) {
+		t.Errorf("got %v, want status 403", err)
+	}
+}
+
+func TestFailureMessage(t *testing.T) {
+	tests := []struct {
+		failure Failure
+		want    string
+	}{
+		{Failure{Status: deeplapi.StatusQuotaExceeded}, "Quota Exceeded"},
+		{Failure{Status: 503}, "Service Unavailable"},
+		{Failure{Status: 503, Message: "Down for maintenance"}, "Down for maintenance"},
+	}
+
+	for _, tt := range tests {
+		srv := NewServer()
+		srv.Fail(tt.failure)
+
+		_, err := translate(srv.API(deeplapi.WithRetryPolicy(deeplapi.NoRetryPolicy())), "Hello", "DE")
+		srv.Close()
+
+		var apiErr *deeplapi.APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("got %v, want an APIError", err)
+		}
+		if apiErr.StatusCode != tt.failure.Status || apiErr.Message != tt.want {
+			t.Errorf("got status %d with message %q, want %d with %q", apiErr.StatusCode, apiErr.Message, tt.failure.Status, tt.want)
+		}
+	}
+}
+
+func TestFailureTimesAndPath(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	srv.Fail(Failure{Path: "/translate", Status: 503, Times: 2})
+	api := srv.API(deeplapi.WithRetryPolicy(deeplapi.NoRetryPolicy()))
+
+	if _, err := api.GetUsage(); err != nil {
+		t.Errorf("other endpoints should not fail, got %v", err)
+	}
+	for range 2 {
+		if _, err := translate(api, "Hello", "DE"); !deeplapi.IsServerError(err) {
+			t.Errorf("got %v, want status 503", err)
+		}
+	}
+	if _, err := translate(api, "Hello", "DE"); err != nil {
+		t.Errorf("failure should be used up, got %v", err)
+	}
+
+	if got := srv.Requests("/translate"); got != 3 {
+		t.Errorf("got %d requests to /translate, want 3", got)
+	}
+	if got := srv.Requests(""); got != 4 {
+		t.Errorf("got %d requests in total, want 4", got)
+	}
+}
+
+func TestCharacterLimit(t *testing.T) {
+	srv := NewServer(WithCharacterLimit(10))
+	defer srv.Close()
+
+	api := srv.API()
+	if _, err := translate(api, "Hello", "DE"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := translate(api, "Hello world", "DE"); !deeplapi.IsQuotaExceeded(err) {
+		t.Errorf("got %v, want status 456", err)
+	}
+
+	usage, err := api.GetUsage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if usage.CharacterCount != 5 || usage.CharacterLimit != 10 {
+		t.Errorf("got %d of %d characters, want 5 of 10", usage.CharacterCount, usage.CharacterLimit)
+	}
+}
+
+func TestUnsupportedParameters(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	api := srv.API()
+	tests := []deeplapi.TranslateParams{
+		{Text: []string{"Hello"}, TargetLang: "XX"},
+		{Text: []string{"Hello"}, SourceLang: "XX", TargetLang: "DE"},
+		{Text: []string{"Hello"}, TargetLang: "EN-GB", Formality: deeplapi.FormalityMore},
+		{Text: []string{"Hello"}, TargetLang: "DE", GlossaryID: "missing"},
+		{Text: make([]string, 51), TargetLang: "DE"},
+	}
+
+	for _, params := range tests {
+		var apiErr *deeplapi.APIError
+		if _, err := api.Translate(params); !errors.As(err, &apiErr) || apiErr.StatusCode != 400 {
+			t.Errorf("%+v: got %v, want status 400", params, err)
+		}
+	}
+
+	// Preferred formalities fall back silently
+	params := deeplapi.TranslateParams{Text: []string{"Hello"}, TargetLang: "EN-GB", Formality: deeplapi.FormalityPreferMore}
+	if _, err := api.Translate(params); err != nil {
+		t.Errorf("got %v, want prefer_more to be accepted", err)
+	}
+}
@@ -0,0 +1,533 @@
+package fake
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	deeplapi "github.com/leschuster/deepl-cli/pkg/deepl-api"
+)
+
+// Limits the fake imposes on a single translation request, like DeepL
+const (
+	maxTextsPerRequest = 50
+	maxRequestSize     = 128 * 1024 // in bytes
+	maxDocumentSize    = 10 << 20   // in bytes
+)
+
+// Languages the fake supports
+var (
+	sourceLanguages = []deeplapi.Language{
+		{Language: "DE", Name: "German"},
+		{Language: "EN", Name: "English"},
+		{Language: "ES", Name: "Spanish"},
+		{Language: "FR", Name: "French"},
+		{Language: "JA", Name: "Japanese"},
+	}
+	targetLanguages = []deeplapi.Language{
+		{Language: "DE", Name: "German", SupportsFormality: true},
+		{Language: "EN-GB", Name: "English (British)"},
+		{Language: "EN-US", Name: "English (American)"},
+		{Language: "ES", Name: "Spanish", SupportsFormality: true},
+		{Language: "FR", Name: "French", SupportsFormality: true},
+		{Language: "JA", Name: "Japanese", SupportsFormality: true},
+	}
+	glossaryLanguagePairs = []deeplapi.GlossaryLanguagePair{
+		{SourceLang: "de", TargetLang: "en"},
+		{SourceLang: "en", TargetLang: "de"},
+		{SourceLang: "en", TargetLang: "es"},
+		{SourceLang: "en", TargetLang: "fr"},
+		{SourceLang: "es", TargetLang: "en"},
+		{SourceLang: "fr", TargetLang: "en"},
+	}
+)
+
+// Glossary stored by the fake
+type glossary struct {
+	deeplapi.Glossary
+	entries []deeplapi.GlossaryEntry
+}
+
+// Document uploaded to the fake
+type document struct {
+	key              string
+	result           []byte
+	billedCharacters int
+}
+
+// Translate a text deterministically, the way the fake does.
+// The translation is the text prefixed with the target language,
+// e.g. "[DE] Hello". Texts translated before are translated from their
+// original, so that "[DE] Hello" becomes "[EN-GB] Hello".
+func Translate(text, targetLang string) string {
+	if text == "" {
+		return ""
+	}
+
+	_, original := detect(text)
+	return fmt.Sprintf("[%s] %s", strings.ToUpper(targetLang), original)
+}
+
+// Helper function to detect the language of a text.
+// Translations of the fake are detected by their prefix, all other texts are English.
+func detect(text string) (lang, original string) {
+	if rest, ok := strings.CutPrefix(text, "["); ok {
+		if code, original, ok := strings.Cut(rest, "] "); ok {
+			if _, known := deeplapi.FindLanguage(targetLanguages, code); known {
+				return deeplapi.BaseCode(code), original
+			}
+		}
+	}
+
+	return "EN", text
+}
+
+// Helper function to register all endpoints
+func (h *Handler) routes() {
+	h.mux.HandleFunc("POST "+basePath+"/translate", h.handleTranslate)
+	h.mux.HandleFunc("GET "+basePath+"/languages", h.handleLanguages)
+	h.mux.HandleFunc("GET "+basePath+"/usage", h.handleUsage)
+
+	h.mux.HandleFunc("POST "+basePath+"/glossaries", h.handleCreateGlossary)
+	h.mux.HandleFunc("GET "+basePath+"/glossaries", h.handleListGlossaries)
+	h.mux.HandleFunc("GET "+basePath+"/glossaries/{id}", h.handleGetGlossary)
+	h.mux.HandleFunc("DELETE "+basePath+"/glossaries/{id}", h.handleDeleteGlossary)
+	h.mux.HandleFunc("GET "+basePath+"/glossaries/{id}/entries", h.handleGlossaryEntries)
+	h.mux.HandleFunc("GET "+basePath+"/glossary-language-pairs", h.handleGlossaryLanguagePairs)
+
+	h.mux.HandleFunc("POST "+basePath+"/document", h.handleUploadDocument)
+	h.mux.HandleFunc("POST "+basePath+"/document/{id}", h.handleDocumentStatus)
+	h.mux.HandleFunc("POST "+basePath+"/document/{id}/result", h.handleDownloadDocument)
+}
+
+// Handle POST /translate
+func (h *Handler) handleTranslate(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxRequestSize+1))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Could not read request body")
+		return
+	}
+	if len(body) > maxRequestSize {
+		writeError(w, http.StatusRequestEntityTooLarge, "Request size exceeds the limit")
+		return
+	}
+
+	params := deeplapi.TranslateParams{}
+	if err := json.Unmarshal(body, &params); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	switch {
+	case len(params.Text) == 0:
+		writeError(w, http.StatusBadRequest, "Parameter 'text' not specified.")
+		return
+	case len(params.Text) > maxTextsPerRequest:
+		writeError(w, http.StatusBadRequest, "Too many texts in request.")
+		return
+	}
+
+	target, ok := h.checkLanguages(w, params.SourceLang, params.TargetLang)
+	if !ok {
+		return
+	}
+
+	if !formalityAllowed(params.Formality, target) {
+		writeError(w, http.StatusBadRequest, "'formality' is not supported for given 'target_lang'.")
+		return
+	}
+
+	var entries []deeplapi.GlossaryEntry
+	if params.GlossaryID != "" {
+		if entries, ok = h.glossaryFor(w, params.GlossaryID, params.SourceLang, params.TargetLang); !ok {
+			return
+		}
+	}
+
+	billed := 0
+	for _, text := range params.Text {
+		billed += utf8.RuneCountInString(text)
+	}
+	if !h.bill(w, billed) {
+		return
+	}
+
+	resp := deeplapi.TranslateResp{}
+	for _, text := range params.Text {
+		detected, original := detect(text)
+		if params.SourceLang != "" {
+			detected = strings.ToUpper(params.SourceLang)
+		}
+
+		t := deeplapi.Translation{
+			DetectedSourceLanguage: detected,
+			Text:                   Translate(applyGlossary(original, entries), params.TargetLang),
+		}
+		if params.ShowBilledCharacters {
+			t.BilledCharacters = utf8.RuneCountInString(text)
+		}
+		if params.ModelType != "" {
+			t.ModelTypeUsed = strings.TrimPrefix(params.ModelType, "prefer_")
+		}
+
+		resp.Translations = append(resp.Translations, t)
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// Handle GET /languages
+func (h *Handler) handleLanguages(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Query().Get("type") {
+	case "", "source":
+		writeJSON(w, http.StatusOK, sourceLanguages)
+	case "target":
+		writeJSON(w, http.StatusOK, targetLanguages)
+	default:
+		writeError(w, http.StatusBadRequest, "Value for 'type' not supported.")
+	}
+}
+
+// Handle GET /usage
+func (h *Handler) handleUsage(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, h.Usage())
+}
+
+// Handle POST /glossaries
+func (h *Handler) handleCreateGlossary(w http.ResponseWriter, r *http.Request) {
+	params := deeplapi.CreateGlossaryParams{}
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	pair := deeplapi.GlossaryLanguagePair{
+		SourceLang: strings.ToLower(params.SourceLang),
+		TargetLang: strings.ToLower(params.TargetLang),
+	}
+	if !slices.Contains(glossaryLanguagePairs, pair) {
+		writeError(w, http.StatusBadRequest, "Unsupported glossary language pair.")
+		return
+	}
+	if params.Name == "" {
+		writeError(w, http.StatusBadRequest, "Parameter 'name' not specified.")
+		return
+	}
+
+	format := params.EntriesFormat
+	if format == "" {
+		format = deeplapi.GlossaryFormatTSV
+	}
+	entries, err := deeplapi.ParseGlossaryEntries(params.Entries, format)
+	if err != nil || len(entries) == 0 {
+		writeError(w, http.StatusBadRequest, "Invalid glossary entries provided")
+		return
+	}
+
+	h.mu.Lock()
+	h.nextID++
+	g := &glossary{
+		Glossary: deeplapi.Glossary{
+			GlossaryID:   fmt.Sprintf("glossary-%d", h.nextID),
+			Name:         params.Name,
+			Ready:        true,
+			SourceLang:   pair.SourceLang,
+			TargetLang:   pair.TargetLang,
+			CreationTime: time.Now().UTC().Format(time.RFC3339),
+			EntryCount:   len(entries),
+		},
+		entries: entries,
+	}
+	h.glossaries[g.GlossaryID] = g
+	h.mu.Unlock()
+
+	writeJSON(w, http.StatusCreated, g.Glossary)
+}
+
+// Handle GET /glossaries
+func (h *Handler) handleListGlossaries(w http.ResponseWriter, r *http.Request) {
+	h.mu.Lock()
+	list := []deeplapi.Glossary{}
+	for _, g := range h.glossaries {
+		list = append(list, g.Glossary)
+	}
+	h.mu.Unlock()
+
+	slices.SortFunc(list, func(a, b deeplapi.Glossary) int {
+		return strings.Compare(a.GlossaryID, b.GlossaryID)
+	})
+
+	writeJSON(w, http.StatusOK, map[string][]deeplapi.Glossary{"glossaries": list})
+}
+
+// Handle GET /glossaries/{id}
+func (h *Handler) handleGetGlossary(w http.ResponseWriter, r *http.Request) {
+	h.mu.Lock()
+	g, ok := h.glossaries[r.PathValue("id")]
+	h.mu.Unlock()
+
+	if !ok {
+		writeError(w, http.StatusNotFound, "Glossary not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, g.Glossary)
+}
+
+// Handle DELETE /glossaries/{id}
+func (h *Handler) handleDeleteGlossary(w http.ResponseWriter, r *http.Request) {
+	h.mu.Lock()
+	_, ok := h.glossaries[r.PathValue("id")]
+	delete(h.glossaries, r.PathValue("id"))
+	h.mu.Unlock()
+
+	if !ok {
+		writeError(w, http.StatusNotFound, "Glossary not found")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Handle GET /glossaries/{id}/entries
+func (h *Handler) handleGlossaryEntries(w http.ResponseWriter, r *http.Request) {
+	h.mu.Lock()
+	g, ok := h.glossaries[r.PathValue("id")]
+	h.mu.Unlock()
+
+	if !ok {
+		writeError(w, http.StatusNotFound, "Glossary not found")
+		return
+	}
+
+	entries, err := deeplapi.FormatGlossaryEntries(g.entries, deeplapi.GlossaryFormatTSV)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/tab-separated-values")
+	io.WriteString(w, entries)
+}
+
+// Handle GET /glossary-language-pairs
+func (h *Handler) handleGlossaryLanguagePairs(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string][]deeplapi.GlossaryLanguagePair{
+		"supported_languages": glossaryLanguagePairs,
+	})
+}
+
+// Handle POST /document.
+// Documents are translated as plain text and are done right away.
+func (h *Handler) handleUploadDocument(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseMultipartForm(maxDocumentSize); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Parameter 'file' not specified.")
+		return
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Could not read document")
+		return
+	}
+
+	sourceLang, targetLang := r.FormValue("source_lang"), r.FormValue("target_lang")
+	target, ok := h.checkLanguages(w, sourceLang, targetLang)
+	if !ok {
+		return
+	}
+
+	if !formalityAllowed(r.FormValue("formality"), target) {
+		writeError(w, http.StatusBadRequest, "'formality' is not supported for given 'target_lang'.")
+		return
+	}
+
+	var entries []deeplapi.GlossaryEntry
+	if id := r.FormValue("glossary_id"); id != "" {
+		if entries, ok = h.glossaryFor(w, id, sourceLang, targetLang); !ok {
+			return
+		}
+	}
+
+	billed := utf8.RuneCount(content)
+	if !h.bill(w, billed) {
+		return
+	}
+
+	_, original := detect(string(content))
+	result := Translate(applyGlossary(original, entries), targetLang)
+
+	h.mu.Lock()
+	h.nextID++
+	handle := deeplapi.DocumentHandle{
+		DocumentID:  fmt.Sprintf("document-%d", h.nextID),
+		DocumentKey: fmt.Sprintf("key-%d", h.nextID),
+	}
+	h.documents[handle.DocumentID] = &document{
+		key:              handle.DocumentKey,
+		result:           []byte(result),
+		billedCharacters: billed,
+	}
+	h.usage.DocumentCount++
+	h.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, handle)
+}
+
+// Handle POST /document/{id}
+func (h *Handler) handleDocumentStatus(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	doc, ok := h.documentFor(w, r, id)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, deeplapi.DocumentStatus{
+		DocumentID:       id,
+		Status:           deeplapi.DocumentStatusDone,
+		BilledCharacters: doc.billedCharacters,
+	})
+}
+
+// Handle POST /document/{id}/result.
+// Like DeepL, the result can only be downloaded once.
+func (h *Handler) handleDownloadDocument(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	doc, ok := h.documentFor(w, r, id)
+	if !ok {
+		return
+	}
+
+	h.mu.Lock()
+	delete(h.documents, id)
+	h.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Write(doc.result)
+}
+
+// Helper function to check the source and target language of a request.
+// Responds with an error and returns false if they are not supported.
+func (h *Handler) checkLanguages(w http.ResponseWriter, sourceLang, targetLang string) (deeplapi.Language, bool) {
+	if _, ok := deeplapi.FindLanguage(sourceLanguages, sourceLang); sourceLang != "" && !ok {
+		writeError(w, http.StatusBadRequest, "Value for 'source_lang' not supported.")
+		return deeplapi.Language{}, false
+	}
+
+	if lang, ok := deeplapi.FindLanguage(targetLanguages, targetLang); ok {
+		return lang, true
+	}
+
+	if targetLang == "" {
+		writeError(w, http.StatusBadRequest, "Parameter 'target_lang' not specified.")
+	} else {
+		writeError(w, http.StatusBadRequest, "Value for 'target_lang' not supported.")
+	}
+	return deeplapi.Language{}, false
+}
+
+// Helper function to get the entries of the glossary for a request.
+// Responds with an error and returns false if the glossary cannot be used.
+func (h *Handler) glossaryFor(w http.ResponseWriter, id, sourceLang, targetLang string) ([]deeplapi.GlossaryEntry, bool) {
+	if sourceLang == "" {
+		writeError(w, http.StatusBadRequest, "Use of a glossary requires the 'source_lang' parameter to be specified.")
+		return nil, false
+	}
+
+	h.mu.Lock()
+	g, ok := h.glossaries[id]
+	h.mu.Unlock()
+
+	switch {
+	case !ok:
+		writeError(w, http.StatusNotFound, "Glossary not found")
+		return nil, false
+	case !strings.EqualFold(g.SourceLang, deeplapi.BaseCode(sourceLang)) || !strings.EqualFold(g.TargetLang, deeplapi.BaseCode(targetLang)):
+		writeError(w, http.StatusBadRequest, "Language pair of the glossary does not match the translation.")
+		return nil, false
+	}
+
+	return g.entries, true
+}
+
+// Helper function to get an uploaded document by the key in the request body.
+// Responds with an error and returns false if there is no such document.
+func (h *Handler) documentFor(w http.ResponseWriter, r *http.Request, id string) (*document, bool) {
+	body := struct {
+		DocumentKey string `json:"document_key"`
+	}{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return nil, false
+	}
+
+	h.mu.Lock()
+	doc, ok := h.documents[id]
+	h.mu.Unlock()
+
+	if !ok || doc.key != body.DocumentKey {
+		writeError(w, http.StatusNotFound, "Document not found")
+		return nil, false
+	}
+
+	return doc, true
+}
+
+// Helper function to count characters towards the usage.
+// Responds with status 456 and returns false if the limit would be exceeded.
+func (h *Handler) bill(w http.ResponseWriter, characters int) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	limit := h.usage.CharacterLimit
+	if limit > 0 && h.usage.CharacterCount+int64(characters) > limit {
+		writeError(w, deeplapi.StatusQuotaExceeded, quotaExceeded)
+		return false
+	}
+
+	h.usage.CharacterCount += int64(characters)
+	return true
+}
+
+// Helper function to replace the source terms of glossary entries with their targets
+func applyGlossary(text string, entries []deeplapi.GlossaryEntry) string {
+	for _, e := range entries {
+		text = strings.ReplaceAll(text, e.Source, e.Target)
+	}
+	return text
+}
+
+// Helper function to check whether a formality can be requested for a target language.
+// Like DeepL, the prefer_* values fall back to the default instead of failing.
+func formalityAllowed(formality string, target deeplapi.Language) bool {
+	switch formality {
+	case "", deeplapi.FormalityDefault, deeplapi.FormalityPreferMore, deeplapi.FormalityPreferLess:
+		return true
+	default:
+		return target.SupportsFormality
+	}
+}
+
+// Helper function to respond with a JSON body
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// Helper function to respond with an error, described the way DeepL does
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"message": message})
+}
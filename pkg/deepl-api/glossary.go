@@ -0,0 +1,230 @@
+package deeplapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Defines the format in which glossary entries are exchanged with DeepL.
+const (
+	GlossaryFormatTSV = "tsv"
+	GlossaryFormatCSV = "csv"
+)
+
+// Glossary holds the meta information of a glossary stored at DeepL
+type Glossary struct {
+	GlossaryID   string `json:"glossary_id"`   // Unique ID assigned to the glossary
+	Name         string `json:"name"`          // Name associated with the glossary
+	Ready        bool   `json:"ready"`         // Whether the glossary can already be used in translations
+	SourceLang   string `json:"source_lang"`   // Language code of the source terms
+	TargetLang   string `json:"target_lang"`   // Language code of the target terms
+	CreationTime string `json:"creation_time"` // Time of creation, ISO 8601
+	EntryCount   int    `json:"entry_count"`   // Number of entries in the glossary
+}
+
+// GlossaryEntry is a single source term and its translation
+type GlossaryEntry struct {
+	Source string
+	Target string
+}
+
+// GlossaryLanguagePair describes a combination of languages glossaries can be created for
+type GlossaryLanguagePair struct {
+	SourceLang string `json:"source_lang"`
+	TargetLang string `json:"target_lang"`
+}
+
+// Parameters for DeeplAPI.CreateGlossary
+// All fields are required
+type CreateGlossaryParams struct {
+	Name          string `json:"name"`           // Name to be associated with the glossary
+	SourceLang    string `json:"source_lang"`    // Language code of the source terms
+	TargetLang    string `json:"target_lang"`    // Language code of the target terms
+	Entries       string `json:"entries"`        // Entries of the glossary, formatted according to EntriesFormat
+	EntriesFormat string `json:"entries_format"` // Format of Entries, either tsv or csv
+}
+
+// The CreateGlossary function creates a new glossary at DeepL
+func (api *DeeplAPI) CreateGlossary(params CreateGlossaryParams) (*Glossary, error) {
+	return api.CreateGlossaryCtx(context.Background(), params)
+}
+
+// CreateGlossaryCtx is like CreateGlossary, but aborts once ctx is done
+func (api *DeeplAPI) CreateGlossaryCtx(ctx context.Context, params CreateGlossaryParams) (*Glossary, error) {
+	body, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal options to JSON: %v", err)
+	}
+
+	data, err := api.request(ctx, "/glossaries", http.MethodPost, body)
+	if err != nil {
+		return nil, err
+	}
+
+	glossary := Glossary{}
+	err = json.Unmarshal(data, &glossary)
+	if err != nil {
+		return nil, fmt.Errorf("could not unmarshall response: %v", err)
+	}
+
+	return &glossary, nil
+}
+
+// The ListGlossaries function retrieves all glossaries of the account
+func (api *DeeplAPI) ListGlossaries() ([]Glossary, error) {
+	return api.ListGlossariesCtx(context.Background())
+}
+
+// ListGlossariesCtx is like ListGlossaries, but aborts once ctx is done
+func (api *DeeplAPI) ListGlossariesCtx(ctx context.Context) ([]Glossary, error) {
+	data, err := api.request(ctx, "/glossaries", http.MethodGet, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := struct {
+		Glossaries []Glossary `json:"glossaries"`
+	}{}
+	err = json.Unmarshal(data, &resp)
+	if err != nil {
+		return nil, fmt.Errorf("could not unmarshall response: %v", err)
+	}
+
+	return resp.Glossaries, nil
+}
+
+// The GetGlossary function retrieves the meta information of a single glossary
+func (api *DeeplAPI) GetGlossary(glossaryID string) (*Glossary, error) {
+	return api.GetGlossaryCtx(context.Background(), glossaryID)
+}
+
+// GetGlossaryCtx is like GetGlossary, but aborts once ctx is done
+func (api *DeeplAPI) GetGlossaryCtx(ctx context.Context, glossaryID string) (*Glossary, error) {
+	data, err := api.request(ctx, "/glossaries/"+url.PathEscape(glossaryID), http.MethodGet, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	glossary := Glossary{}
+	err = json.Unmarshal(data, &glossary)
+	if err != nil {
+		return nil, fmt.Errorf("could not unmarshall response: %v", err)
+	}
+
+	return &glossary, nil
+}
+
+// The DeleteGlossary function deletes a glossary at DeepL
+func (api *DeeplAPI) DeleteGlossary(glossaryID string) error {
+	return api.DeleteGlossaryCtx(context.Background(), glossaryID)
+}
+
+// DeleteGlossaryCtx is like DeleteGlossary, but aborts once ctx is done
+func (api *DeeplAPI) DeleteGlossaryCtx(ctx context.Context, glossaryID string) error {
+	_, err := api.request(ctx, "/glossaries/"+url.PathEscape(glossaryID), http.MethodDelete, nil)
+	return err
+}
+
+// The GetGlossaryEntries function retrieves all entries of a glossary
+func (api *DeeplAPI) GetGlossaryEntries(glossaryID string) ([]GlossaryEntry, error) {
+	return api.GetGlossaryEntriesCtx(context.Background(), glossaryID)
+}
+
+// GetGlossaryEntriesCtx is like GetGlossaryEntries, but aborts once ctx is done
+func (api *DeeplAPI) GetGlossaryEntriesCtx(ctx context.Context, glossaryID string) ([]GlossaryEntry, error) {
+	// DeepL responds with tab-separated values
+	data, err := api.request(ctx, "/glossaries/"+url.PathEscape(glossaryID)+"/entries", http.MethodGet, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseGlossaryEntries(string(data), GlossaryFormatTSV)
+}
+
+// The GetGlossaryLanguagePairs function retrieves all language combinations
+// that glossaries are supported for
+func (api *DeeplAPI) GetGlossaryLanguagePairs() ([]GlossaryLanguagePair, error) {
+	return api.GetGlossaryLanguagePairsCtx(context.Background())
+}
+
+// GetGlossaryLanguagePairsCtx is like GetGlossaryLanguagePairs, but aborts once ctx is done
+func (api *DeeplAPI) GetGlossaryLanguagePairsCtx(ctx context.Context) ([]GlossaryLanguagePair, error) {
+	data, err := api.request(ctx, "/glossary-language-pairs", http.MethodGet, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := struct {
+		SupportedLanguages []GlossaryLanguagePair `json:"supported_languages"`
+	}{}
+	err = json.Unmarshal(data, &resp)
+	if err != nil {
+		return nil, fmt.Errorf("could not unmarshall response: %v", err)
+	}
+
+	return resp.SupportedLanguages, nil
+}
+
+// ParseGlossaryEntries reads entries from their tsv or csv representation
+func ParseGlossaryEntries(entries, format string) ([]GlossaryEntry, error) {
+	r := csv.NewReader(strings.NewReader(entries))
+	r.FieldsPerRecord = 2
+
+	switch format {
+	case GlossaryFormatTSV:
+		r.Comma = '\t'
+		r.LazyQuotes = true // TSV does not know about quoting
+	case GlossaryFormatCSV:
+	default:
+		return nil, fmt.Errorf("unknown glossary format '%s'", format)
+	}
+
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("could not parse glossary entries: %v", err)
+	}
+
+	res := make([]GlossaryEntry, len(records))
+	for i, rec := range records {
+		res[i] = GlossaryEntry{Source: rec[0], Target: rec[1]}
+	}
+
+	return res, nil
+}
+
+// FormatGlossaryEntries writes entries in their tsv or csv representation,
+// ready to be used as CreateGlossaryParams.Entries
+func FormatGlossaryEntries(entries []GlossaryEntry, format string) (string, error) {
+	var buf bytes.Buffer
+
+	switch format {
+	case GlossaryFormatTSV:
+		for _, e := range entries {
+			if strings.ContainsAny(e.Source+e.Target, "\t\n") {
+				return "", fmt.Errorf("glossary entry '%s' contains a tab or newline", e.Source)
+			}
+			fmt.Fprintf(&buf, "%s\t%s\n", e.Source, e.Target)
+		}
+	case GlossaryFormatCSV:
+		w := csv.NewWriter(&buf)
+		for _, e := range entries {
+			if err := w.Write([]string{e.Source, e.Target}); err != nil {
+				return "", fmt.Errorf("could not format glossary entries: %v", err)
+			}
+		}
+		w.Flush()
+		if err := w.Error(); err != nil {
+			return "", fmt.Errorf("could not format glossary entries: %v", err)
+		}
+	default:
+		return "", fmt.Errorf("unknown glossary format '%s'", format)
+	}
+
+	return buf.String(), nil
+}
@@ -0,0 +1,94 @@
+package deeplapi_test
+
+import (
+	"slices"
+	"testing"
+
+	deeplapi "github.com/leschuster/deepl-cli/pkg/deepl-api"
+	"github.com/leschuster/deepl-cli/pkg/deepl-api/fake"
+)
+
+func TestGlossaryRoundTrip(t *testing.T) {
+	srv := fake.NewServer()
+	defer srv.Close()
+
+	api := srv.API()
+	entries := []deeplapi.GlossaryEntry{{Source: "Hello", Target: "Servus"}, {Source: "world", Target: "Welt"}}
+
+	tsv, err := deeplapi.FormatGlossaryEntries(entries, "tsv")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	created, err := api.CreateGlossary(deeplapi.CreateGlossaryParams{
+		Name:          "Greetings",
+		SourceLang:    "en",
+		TargetLang:    "de",
+		Entries:       tsv,
+		EntriesFormat: "tsv",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.EntryCount != len(entries) || !created.Ready {
+		t.Errorf("got %+v, want a ready glossary with %d entries", created, len(entries))
+	}
+
+	list, err := api.ListGlossaries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 1 || list[0].GlossaryID != created.GlossaryID {
+		t.Errorf("got %+v, want only the created glossary", list)
+	}
+
+	got, err := api.GetGlossary(created.GlossaryID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Name != "Greetings" {
+		t.Errorf("got name %q, want Greetings", got.Name)
+	}
+
+	gotEntries, err := api.GetGlossaryEntries(created.GlossaryID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(gotEntries, entries) {
+		t.Errorf("got entries %v, want %v", gotEntries, entries)
+	}
+
+	resp, err := api.Translate(deeplapi.TranslateParams{
+		Text:       []string{"Hello world"},
+		SourceLang: "EN",
+		TargetLang: "DE",
+		GlossaryID: created.GlossaryID,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := resp.Translations[0].Text; got != "[DE] Servus Welt" {
+		t.Errorf("got %q, want the glossary to be applied", got)
+	}
+
+	if err := api.DeleteGlossary(created.GlossaryID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := api.GetGlossary(created.GlossaryID); !hasStatus(err, 404) {
+		t.Errorf("got %v, want status 404 after deleting", err)
+	}
+}
+
+func TestGlossaryLanguagePairs(t *testing.T) {
+	srv := fake.NewServer()
+	defer srv.Close()
+
+	pairs, err := srv.API().GetGlossaryLanguagePairs()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Contains(pairs, deeplapi.GlossaryLanguagePair{SourceLang: "en", TargetLang: "de"}) {
+		t.Errorf("got %v, want en to de to be supported", pairs)
+	}
+}
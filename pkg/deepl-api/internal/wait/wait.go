@@ -0,0 +1,27 @@
+// Package wait provides a sleep that can be interrupted.
+// It is shared by the DeepL client and the fake server.
+
+package wait
+
+import (
+	"context"
+	"time"
+)
+
+// Wait for the given duration.
+// Returns early with an error once ctx is done.
+func For(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,20 @@
+package deeplapi
+
+import "strings"
+
+// Find a language by its code, ignoring case
+func FindLanguage(langs []Language, code string) (Language, bool) {
+	for _, lang := range langs {
+		if strings.EqualFold(lang.Language, code) {
+			return lang, true
+		}
+	}
+
+	return Language{}, false
+}
+
+// Strip the regional variant of a language code, e.g. EN-GB becomes EN
+func BaseCode(code string) string {
+	base, _, _ := strings.Cut(strings.ToUpper(code), "-")
+	return base
+}
@@ -0,0 +1,26 @@
+package deeplapi_test
+
+import (
+	"testing"
+
+	deeplapi "github.com/leschuster/deepl-cli/pkg/deepl-api"
+)
+
+func TestFindLanguage(t *testing.T) {
+	langs := []deeplapi.Language{{Language: "DE"}, {Language: "EN-GB"}}
+
+	if lang, ok := deeplapi.FindLanguage(langs, "en-gb"); !ok || lang.Language != "EN-GB" {
+		t.Errorf("got %v, %v, want EN-GB", lang, ok)
+	}
+	if _, ok := deeplapi.FindLanguage(langs, "EN"); ok {
+		t.Error("expected EN not to match EN-GB")
+	}
+}
+
+func TestBaseCode(t *testing.T) {
+	for code, want := range map[string]string{"EN-GB": "EN", "pt-br": "PT", "de": "DE", "": ""} {
+		if got := deeplapi.BaseCode(code); got != want {
+			t.Errorf("BaseCode(%q) = %q, want %q", code, got, want)
+		}
+	}
+}
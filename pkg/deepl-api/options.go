@@ -0,0 +1,96 @@
+package deeplapi
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Option changes how a DeeplAPI instance connects to DeepL, see New
+type Option func(*options)
+
+// Settings collected from the options passed to New
+type options struct {
+	baseURL   string
+	client    *http.Client
+	timeout   *time.Duration
+	proxy     *url.URL
+	userAgent string
+	retry     *RetryPolicy
+}
+
+// Send requests to another base URL than the one derived from the API key,
+// e.g. a regional endpoint or a DeepL-compatible mock like "http://localhost:8080/v2"
+func WithBaseURL(baseURL string) Option {
+	return func(o *options) {
+		o.baseURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// Perform requests with the given client instead of a new one.
+// WithTimeout and WithProxy are applied to a copy of the client.
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *options) {
+		o.client = client
+	}
+}
+
+// Set the upper bound for a single request, including reading the response body.
+// Zero disables the timeout. Defaults to two minutes.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.timeout = &timeout
+	}
+}
+
+// Send all requests through a proxy, e.g. "http://proxy.example.com:3128".
+// By default, the proxy is taken from the HTTPS_PROXY and NO_PROXY environment variables.
+func WithProxy(proxy *url.URL) Option {
+	return func(o *options) {
+		o.proxy = proxy
+	}
+}
+
+// Identify the application with the User-Agent header of every request
+func WithUserAgent(userAgent string) Option {
+	return func(o *options) {
+		o.userAgent = userAgent
+	}
+}
+
+// Set the policy for retrying failed requests, see DefaultRetryPolicy
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(o *options) {
+		o.retry = &policy
+	}
+}
+
+// Helper function to get the HTTP client to perform requests with
+func (o options) httpClient() *http.Client {
+	client := &http.Client{Timeout: defaultTimeout}
+	if o.client != nil {
+		// Copy to leave the client of the caller untouched
+		c := *o.client
+		client = &c
+	}
+
+	if o.timeout != nil {
+		client.Timeout = *o.timeout
+	}
+
+	if o.proxy != nil {
+		// Only the standard transport can be configured
+		transport, ok := client.Transport.(*http.Transport)
+		if client.Transport == nil {
+			transport, ok = http.DefaultTransport.(*http.Transport)
+		}
+		if ok {
+			transport = transport.Clone()
+			transport.Proxy = http.ProxyURL(o.proxy)
+			client.Transport = transport
+		}
+	}
+
+	return client
+}
@@ -0,0 +1,129 @@
+package deeplapi
+
+import (
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RetryPolicy defines how often and how long to wait before a failed request is repeated.
+// Requests are only retried if DeepL asked us to slow down (429) or if it was temporarily
+// unable to process a request that is safe to repeat (5xx, connection errors).
+type RetryPolicy struct {
+	MaxAttempts int           // Total number of attempts per request, 1 disables retrying
+	BaseDelay   time.Duration // Delay before the first retry, doubled with every further attempt
+	MaxDelay    time.Duration // Upper bound for the delay between two attempts
+	Jitter      float64       // Random deviation of the delay as a fraction, e.g. 0.2 for ±20%
+}
+
+// DefaultRetryPolicy returns the policy used by DeeplAPI instances created with New
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 5,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    30 * time.Second,
+		Jitter:      0.2,
+	}
+}
+
+// NoRetryPolicy returns a policy that never repeats a failed request
+func NoRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 1,
+	}
+}
+
+// Set the policy for retrying failed requests
+func (api *DeeplAPI) SetRetryPolicy(policy RetryPolicy) {
+	api.retry = policy
+}
+
+// Calculate how long to wait before the next attempt.
+// attempt is the number of attempts made so far, starting at 1.
+// A Retry-After duration requested by DeepL takes precedence, but does not exceed MaxDelay.
+func (p RetryPolicy) delay(attempt int, retryAfter time.Duration) time.Duration {
+	if retryAfter > 0 {
+		if p.MaxDelay > 0 {
+			return min(retryAfter, p.MaxDelay)
+		}
+		return retryAfter
+	}
+
+	d := p.BaseDelay
+	for i := 1; i < attempt && d < p.MaxDelay; i++ {
+		d *= 2
+	}
+	if p.MaxDelay > 0 {
+		d = min(d, p.MaxDelay)
+	}
+
+	if p.Jitter > 0 {
+		deviation := (rand.Float64()*2 - 1) * p.Jitter // in [-Jitter, Jitter)
+		d = time.Duration(float64(d) * (1 + deviation))
+	}
+
+	return max(d, 0)
+}
+
+// Decide whether a request should be attempted again.
+// err is the error of the last attempt, idempotent whether the request can safely be repeated.
+func (p RetryPolicy) shouldRetry(attempt int, err error, idempotent bool) bool {
+	if attempt >= p.MaxAttempts {
+		return false
+	}
+
+	switch {
+	case IsRateLimited(err):
+		// DeepL did not process the request at all
+		return true
+	case IsServerError(err):
+		// The request might have been processed partially
+		return idempotent
+	case err != nil && !isAPIError(err):
+		// Connection errors, the request might not have reached DeepL
+		return idempotent
+	default:
+		return false
+	}
+}
+
+// Helper function to determine whether a request can be repeated without side effects.
+// Some endpoints only read data although they are called with POST.
+func isIdempotent(method, endpoint string) bool {
+	if method != http.MethodPost {
+		return true
+	}
+
+	switch {
+	case endpoint == "/translate":
+		return true
+	case strings.HasPrefix(endpoint, "/document/") && strings.HasSuffix(endpoint, "/result"):
+		// DeepL serves the result of a document only once
+		return false
+	case strings.HasPrefix(endpoint, "/document/"):
+		// Checking the status of a document
+		return true
+	default:
+		return false
+	}
+}
+
+// Helper function to parse the Retry-After header.
+// It contains either a number of seconds or an HTTP date.
+func parseRetryAfter(header string) time.Duration {
+	if header == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(header); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0)
+	}
+
+	if date, err := http.ParseTime(header); err == nil {
+		return max(time.Until(date), 0)
+	}
+
+	return 0
+}
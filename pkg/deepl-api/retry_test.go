@@ -0,0 +1,147 @@
+package deeplapi_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	deeplapi "github.com/leschuster/deepl-cli/pkg/deepl-api"
+	"github.com/leschuster/deepl-cli/pkg/deepl-api/fake"
+)
+
+// Retry policy that does not slow down the tests
+var fastRetry = deeplapi.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}
+
+// Helper function to translate a single text
+func translate(api *deeplapi.DeeplAPI, text string) (*deeplapi.TranslateResp, error) {
+	return api.Translate(deeplapi.TranslateParams{Text: []string{text}, TargetLang: "DE"})
+}
+
+func TestRetryHonorsRetryAfter(t *testing.T) {
+	srv := fake.NewServer()
+	defer srv.Close()
+
+	srv.Fail(fake.Failure{Path: "/translate", Status: deeplapi.StatusTooManyRequests, Times: 1, RetryAfter: time.Second})
+	api := srv.API(deeplapi.WithRetryPolicy(fastRetry))
+
+	start := time.Now()
+	resp, err := translate(api, "Hello")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := resp.Translations[0].Text; got != "[DE] Hello" {
+		t.Errorf("got %q, want [DE] Hello", got)
+	}
+	if got := srv.Requests("/translate"); got != 2 {
+		t.Errorf("got %d requests, want 2", got)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("retried after %v, want at least the requested second", elapsed)
+	}
+}
+
+func TestRetryGivesUpAfterMaxAttempts(t *testing.T) {
+	srv := fake.NewServer()
+	defer srv.Close()
+
+	srv.Fail(fake.Failure{Path: "/translate", Status: 503})
+	api := srv.API(deeplapi.WithRetryPolicy(fastRetry))
+
+	if _, err := translate(api, "Hello"); !deeplapi.IsServerError(err) {
+		t.Errorf("got %v, want status 503", err)
+	}
+	if got := srv.Requests("/translate"); got != fastRetry.MaxAttempts {
+		t.Errorf("got %d requests, want %d", got, fastRetry.MaxAttempts)
+	}
+}
+
+func TestNoRetryOnQuotaExceeded(t *testing.T) {
+	srv := fake.NewServer()
+	defer srv.Close()
+
+	srv.Fail(fake.Failure{Path: "/translate", Status: deeplapi.StatusQuotaExceeded, Times: 1})
+	api := srv.API(deeplapi.WithRetryPolicy(fastRetry))
+
+	if _, err := translate(api, "Hello"); !deeplapi.IsQuotaExceeded(err) {
+		t.Errorf("got %v, want status 456", err)
+	}
+	if got := srv.Requests("/translate"); got != 1 {
+		t.Errorf("got %d requests, want 1", got)
+	}
+}
+
+func TestNoRetryOfNonIdempotentRequests(t *testing.T) {
+	srv := fake.NewServer()
+	defer srv.Close()
+
+	srv.Fail(fake.Failure{Path: "/glossaries", Status: 503, Times: 1})
+	api := srv.API(deeplapi.WithRetryPolicy(fastRetry))
+
+	_, err := api.CreateGlossary(deeplapi.CreateGlossaryParams{
+		Name:          "Test",
+		SourceLang:    "en",
+		TargetLang:    "de",
+		Entries:       "Hello\tHallo",
+		EntriesFormat: "tsv",
+	})
+	if !deeplapi.IsServerError(err) {
+		t.Errorf("got %v, want status 503", err)
+	}
+	if got := srv.Requests("/glossaries"); got != 1 {
+		t.Errorf("got %d requests, want 1", got)
+	}
+}
+
+func TestRetryAfterIsCappedByMaxDelay(t *testing.T) {
+	srv := fake.NewServer()
+	defer srv.Close()
+
+	srv.Fail(fake.Failure{Path: "/translate", Status: deeplapi.StatusTooManyRequests, Times: 1, RetryAfter: time.Hour})
+	api := srv.API(deeplapi.WithRetryPolicy(deeplapi.RetryPolicy{MaxAttempts: 2, MaxDelay: 10 * time.Millisecond}))
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := translate(api, "Hello")
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Error(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("waited for the Retry-After of an hour")
+	}
+}
+
+func TestNoRetryOfDocumentDownload(t *testing.T) {
+	srv := fake.NewServer()
+	defer srv.Close()
+
+	api := srv.API(deeplapi.WithRetryPolicy(fastRetry))
+	handle, err := api.UploadDocument(deeplapi.DocumentParams{
+		File:       strings.NewReader("Hello"),
+		Filename:   "hello.txt",
+		TargetLang: "DE",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The status can be checked again and again
+	srv.Fail(fake.Failure{Path: "/document/{id}", Status: 503, Times: 1})
+	if _, err := api.GetDocumentStatus(*handle); err != nil {
+		t.Errorf("got %v, want the status request to be retried", err)
+	}
+
+	// The result might have been served already, so the download is not repeated
+	srv.Fail(fake.Failure{Path: "/document/{id}/result", Status: 503, Times: 1})
+	if _, err := api.DownloadDocument(*handle); !deeplapi.IsServerError(err) {
+		t.Errorf("got %v, want status 503", err)
+	}
+	if got := srv.Requests("/document/{id}/result"); got != 1 {
+		t.Errorf("got %d download requests, want 1", got)
+	}
+}
@@ -0,0 +1,20 @@
+package deeplapi
+
+import "context"
+
+// Translator is a translation engine the user interface can work with.
+// DeeplAPI is the default implementation, others can be used to work
+// offline or to translate with other engines.
+type Translator interface {
+	// Translate params.Text into params.TargetLang, abort once ctx is done
+	TranslateCtx(ctx context.Context, params TranslateParams) (*TranslateResp, error)
+
+	// Retrieve all supported source and target languages, abort once ctx is done
+	GetLanguagesCtx(ctx context.Context) (*GetLanguagesResp, error)
+
+	// Retrieve how much of the allowance has been consumed, abort once ctx is done
+	GetUsageCtx(ctx context.Context) (*Usage, error)
+}
+
+// Make sure that DeeplAPI implements Translator
+var _ Translator = (*DeeplAPI)(nil)
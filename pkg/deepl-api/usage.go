@@ -0,0 +1,53 @@
+package deeplapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Response type for DeeplAPI.GetUsage
+// The document and team document fields are only available for Pro accounts.
+type Usage struct {
+	CharacterCount    int64 `json:"character_count"`     // Characters translated in the current billing period
+	CharacterLimit    int64 `json:"character_limit"`     // Maximum number of characters per billing period
+	DocumentCount     int64 `json:"document_count"`      // Documents translated in the current billing period
+	DocumentLimit     int64 `json:"document_limit"`      // Maximum number of documents per billing period
+	TeamDocumentCount int64 `json:"team_document_count"` // Documents translated by the whole team in the current billing period
+	TeamDocumentLimit int64 `json:"team_document_limit"` // Maximum number of documents for the whole team per billing period
+}
+
+// Share of the character limit that has been used up, between 0 and 1
+func (u *Usage) CharacterRatio() float64 {
+	if u.CharacterLimit <= 0 {
+		return 0
+	}
+	return min(float64(u.CharacterCount)/float64(u.CharacterLimit), 1)
+}
+
+// Whether the character limit has been reached
+func (u *Usage) CharacterLimitReached() bool {
+	return u.CharacterLimit > 0 && u.CharacterCount >= u.CharacterLimit
+}
+
+// The GetUsage function retrieves how much of the monthly allowance has been consumed
+func (api *DeeplAPI) GetUsage() (*Usage, error) {
+	return api.GetUsageCtx(context.Background())
+}
+
+// GetUsageCtx is like GetUsage, but aborts once ctx is done
+func (api *DeeplAPI) GetUsageCtx(ctx context.Context) (*Usage, error) {
+	data, err := api.request(ctx, "/usage", http.MethodGet, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	usage := Usage{}
+	err = json.Unmarshal(data, &usage)
+	if err != nil {
+		return nil, fmt.Errorf("could not unmarshall response: %v", err)
+	}
+
+	return &usage, nil
+}
@@ -0,0 +1,251 @@
+// Package history provides a persistent record of past translations.
+// Entries are stored as JSON lines in a single file.
+
+package history
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	deeplapi "github.com/leschuster/deepl-cli/pkg/deepl-api"
+)
+
+// Maximum number of entries kept, older ones are dropped
+const DefaultMaxEntries = 1000
+
+// Entry is a single past translation
+type Entry struct {
+	ID           string             `json:"id"`
+	Time         time.Time          `json:"time"`
+	SourceText   string             `json:"source_text"`
+	Source       *deeplapi.Language `json:"source,omitempty"` // nil if the source language was detected automatically
+	Target       deeplapi.Language  `json:"target"`
+	Formality    string             `json:"formality,omitempty"`
+	DetectedLang string             `json:"detected_lang,omitempty"` // Source language detected by DeepL
+	Result       string             `json:"result"`
+}
+
+// Store reads and writes history entries from and to a file
+type Store struct {
+	path       string
+	maxEntries int
+	lines      int // Number of lines in the file, -1 if not counted yet
+	mu         *sync.Mutex
+}
+
+// Creates a new store backed by the file at path.
+// A maxEntries of 0 keeps all entries.
+func New(path string, maxEntries int) *Store {
+	return &Store{
+		path:       path,
+		maxEntries: maxEntries,
+		lines:      -1,
+		mu:         &sync.Mutex{},
+	}
+}
+
+// DefaultPath returns the file the history is stored in by default,
+// e.g. $XDG_CONFIG_HOME/deepl-cli/history.jsonl on Linux
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("could not determine config directory: %v", err)
+	}
+
+	return filepath.Join(dir, "deepl-cli", "history.jsonl"), nil
+}
+
+// Add an entry to the history.
+// ID and Time are set automatically if they are empty.
+func (s *Store) Add(e Entry) error {
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	if e.ID == "" {
+		e.ID = strconv.FormatInt(e.Time.UnixNano(), 36)
+	}
+
+	line, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("could not marshal history entry: %v", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.lines < 0 {
+		if s.lines, err = s.countLines(); err != nil {
+			return err
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return fmt.Errorf("could not create history directory: %v", err)
+	}
+
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("could not open history: %v", err)
+	}
+
+	_, err = f.Write(append(line, '\n'))
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("could not write history: %v", err)
+	}
+
+	s.lines++
+
+	// Drop the oldest entries once there are clearly too many,
+	// so that the file is not rewritten every time an entry is added
+	if s.maxEntries <= 0 || s.lines <= s.maxEntries+max(s.maxEntries/10, 1) {
+		return nil
+	}
+
+	entries, err := s.read()
+	if err != nil {
+		return err
+	}
+
+	return s.write(s.newest(entries))
+}
+
+// Get all entries, the newest first
+func (s *Store) List() ([]Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+	entries = s.newest(entries)
+
+	// Reverse order
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+
+	return entries, nil
+}
+
+// Remove the entry with the given ID
+func (s *Store) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries, err := s.read()
+	if err != nil {
+		return err
+	}
+
+	res := entries[:0]
+	for _, e := range entries {
+		if e.ID != id {
+			res = append(res, e)
+		}
+	}
+
+	return s.write(res)
+}
+
+// Remove all entries
+func (s *Store) Clear() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.Remove(s.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("could not clear history: %v", err)
+	}
+	s.lines = 0
+
+	return nil
+}
+
+// Helper function to drop all but the newest maxEntries entries
+func (s *Store) newest(entries []Entry) []Entry {
+	if s.maxEntries > 0 && len(entries) > s.maxEntries {
+		return entries[len(entries)-s.maxEntries:]
+	}
+	return entries
+}
+
+// Helper function to count the lines of the file without parsing them.
+// The caller must hold s.mu.
+func (s *Store) countLines() (int, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, nil // No history yet
+	}
+	if err != nil {
+		return 0, fmt.Errorf("could not read history: %v", err)
+	}
+
+	return bytes.Count(data, []byte{'\n'}), nil
+}
+
+// Helper function to read all entries, the oldest first.
+// Lines that cannot be parsed are skipped. The caller must hold s.mu.
+func (s *Store) read() ([]Entry, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil // No history yet
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not read history: %v", err)
+	}
+
+	var entries []Entry
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, len(data)+1) // Entries may contain long texts
+	for scanner.Scan() {
+		e := Entry{}
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("could not read history: %v", err)
+	}
+
+	return entries, nil
+}
+
+// Helper function to replace all entries. The caller must hold s.mu.
+func (s *Store) write(entries []Entry) error {
+	var buf bytes.Buffer
+	for _, e := range entries {
+		line, err := json.Marshal(e)
+		if err != nil {
+			return fmt.Errorf("could not marshal history entry: %v", err)
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+
+	// Write to a temporary file first so that the history is never lost halfway
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0o600); err != nil {
+		return fmt.Errorf("could not write history: %v", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("could not write history: %v", err)
+	}
+	s.lines = len(entries)
+
+	return nil
+}
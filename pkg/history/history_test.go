@@ -0,0 +1,144 @@
+package history
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	deeplapi "github.com/leschuster/deepl-cli/pkg/deepl-api"
+)
+
+// Helper function to add entries whose source texts are numbered from first to last
+func addEntries(t *testing.T, s *Store, first, last int) {
+	t.Helper()
+
+	for i := first; i <= last; i++ {
+		err := s.Add(Entry{
+			SourceText: fmt.Sprintf("text %d", i),
+			Target:     deeplapi.Language{Language: "DE"},
+			Result:     fmt.Sprintf("Text %d", i),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// Helper function to count the lines of a file
+func countLines(t *testing.T, path string) int {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return bytes.Count(data, []byte{'\n'})
+}
+
+func TestAddAndList(t *testing.T) {
+	s := New(filepath.Join(t.TempDir(), "history", "history.jsonl"), DefaultMaxEntries)
+	addEntries(t, s, 1, 3)
+
+	entries, err := s.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 3 {
+		t.Fatalf("got %d entries, want 3", len(entries))
+	}
+	for i, e := range entries {
+		if want := fmt.Sprintf("text %d", 3-i); e.SourceText != want {
+			t.Errorf("entry %d: got %q, want %q, the newest first", i, e.SourceText, want)
+		}
+		if e.ID == "" || e.Time.IsZero() {
+			t.Errorf("entry %d: expected ID and time to be set", i)
+		}
+	}
+}
+
+func TestTrimToMaxEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	s := New(path, 10)
+	addEntries(t, s, 1, 35)
+
+	entries, err := s.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 10 {
+		t.Fatalf("got %d entries, want 10", len(entries))
+	}
+	if entries[0].SourceText != "text 35" || entries[9].SourceText != "text 26" {
+		t.Errorf("got entries from %q to %q, want the newest ones", entries[9].SourceText, entries[0].SourceText)
+	}
+
+	// The file may grow a little beyond the limit before it is trimmed
+	if lines := countLines(t, path); lines > 11 {
+		t.Errorf("got %d lines in the file, want at most 11", lines)
+	}
+}
+
+func TestTrimCountsExistingEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	addEntries(t, New(path, 0), 1, 20)
+
+	// A new store, e.g. after a restart, trims entries written before
+	s := New(path, 10)
+	addEntries(t, s, 21, 22)
+
+	if lines := countLines(t, path); lines > 11 {
+		t.Errorf("got %d lines in the file, want at most 11", lines)
+	}
+	if entries, _ := s.List(); len(entries) != 10 || entries[0].SourceText != "text 22" {
+		t.Errorf("got %d entries, want the newest 10", len(entries))
+	}
+}
+
+func TestCorruptLinesAreSkipped(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	s := New(path, DefaultMaxEntries)
+	addEntries(t, s, 1, 1)
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString("{\"id\": \"broken\n")
+	f.Close()
+
+	addEntries(t, s, 2, 2)
+
+	entries, err := s.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || entries[0].SourceText != "text 2" || entries[1].SourceText != "text 1" {
+		t.Errorf("got %+v, want both valid entries", entries)
+	}
+}
+
+func TestDeleteAndClear(t *testing.T) {
+	s := New(filepath.Join(t.TempDir(), "history.jsonl"), DefaultMaxEntries)
+	addEntries(t, s, 1, 3)
+
+	entries, _ := s.List()
+	if err := s.Delete(entries[1].ID); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, _ = s.List()
+	if len(entries) != 2 || entries[0].SourceText != "text 3" || entries[1].SourceText != "text 1" {
+		t.Errorf("got %+v, want text 2 to be deleted", entries)
+	}
+
+	if err := s.Clear(); err != nil {
+		t.Fatal(err)
+	}
+	if entries, _ = s.List(); len(entries) != 0 {
+		t.Errorf("got %d entries after clearing, want none", len(entries))
+	}
+}
@@ -6,6 +6,7 @@ package com
 import (
 	tea "github.com/charmbracelet/bubbletea"
 	deeplapi "github.com/leschuster/deepl-cli/pkg/deepl-api"
+	"github.com/leschuster/deepl-cli/pkg/history"
 )
 
 // Represents the event that an error occured
@@ -44,6 +45,38 @@ func APIKeyEnteredCmd(key string) func() tea.Msg {
 	}
 }
 
+// The entered API key has been accepted by DeepL
+type APIKeyValidatedMsg struct {
+	Key   string
+	Usage deeplapi.Usage
+}
+
+// The entered API key could not be verified, e.g. because DeepL rejected it
+type APIKeyRejectedMsg struct {
+	Err error
+}
+
+// Describes the action of the user wanting to enter another API key
+type APIKeyChangeRequestedMsg struct{}
+
+// Command to trigger APIKeyChangeRequested
+func APIKeyChangeRequestedCmd() func() tea.Msg {
+	return func() tea.Msg {
+		return APIKeyChangeRequestedMsg{}
+	}
+}
+
+// Describes the action of the user leaving the login view
+// without entering an API key
+type LoginCancelledMsg struct{}
+
+// Command to trigger LoginCancelled
+func LoginCancelledCmd() func() tea.Msg {
+	return func() tea.Msg {
+		return LoginCancelledMsg{}
+	}
+}
+
 // Describes that the size available to a view changed
 // Difference to tea.WindowSizeMsg: this one contains the screen height substracted
 // by header and footer height, and the screen width substracted by global margins
@@ -85,6 +118,39 @@ func TarLangSelectedCmd(language deeplapi.Language) func() tea.Msg {
 	}
 }
 
+// Describes the action of the user pasting text from the clipboard
+type ClipboardPastedMsg struct {
+	Text string
+}
+
+// The default languages from the config have been resolved.
+// A language is nil if it is not configured.
+type DefaultLanguagesLoadedMsg struct {
+	Source *deeplapi.Language
+	Target *deeplapi.Language
+}
+
+// Describes the action of the user swapping source and target language.
+// The translation becomes the new source text and vice versa.
+type LanguagesSwappedMsg struct {
+	Source     deeplapi.Language
+	Target     deeplapi.Language
+	SourceText string
+	TargetText string
+}
+
+// Command to trigger LanguagesSwapped
+func LanguagesSwappedCmd(source, target deeplapi.Language, sourceText, targetText string) func() tea.Msg {
+	return func() tea.Msg {
+		return LanguagesSwappedMsg{
+			Source:     source,
+			Target:     target,
+			SourceText: sourceText,
+			TargetText: targetText,
+		}
+	}
+}
+
 // Describes the action of the user selecting a formality
 // for the translation
 type FormalitySelectedMsg struct {
@@ -100,6 +166,21 @@ func FormalitySelectedCmd(formality string) func() tea.Msg {
 	}
 }
 
+// Describes the action of the user selecting whether the text
+// contains HTML or XML markup
+type TagHandlingSelectedMsg struct {
+	TagHandling string
+}
+
+// Command to trigger TagHandlingSelected
+func TagHandlingSelectedCmd(tagHandling string) func() tea.Msg {
+	return func() tea.Msg {
+		return TagHandlingSelectedMsg{
+			TagHandling: tagHandling,
+		}
+	}
+}
+
 // Describes the action of the user selecting the source language button
 type SrcLangBtnSelectedMsg struct{}
 
@@ -130,6 +211,16 @@ func FormalityBtnSelectedCmd() func() tea.Msg {
 	}
 }
 
+// Describes the action of the user selecting the tag handling button
+type TagHandlingBtnSelectedMsg struct{}
+
+// Command to trigger TagHandlingBtnSelected
+func TagHandlingBtnSelectedCmd() func() tea.Msg {
+	return func() tea.Msg {
+		return TagHandlingBtnSelectedMsg{}
+	}
+}
+
 // Describes the action of the user selecting the translate button
 type TranslateBtnSelectedMsg struct{}
 
@@ -172,12 +263,112 @@ func APILanguagesReceivedCmd() func() tea.Msg {
 }
 
 // Previously requested translation has been received
-type APITranslationReceivedMsg struct{}
+type APITranslationReceivedMsg struct {
+	Seq      uint64 // Number of the request, responses to older requests are stale
+	Live     bool   // Whether the translation was triggered by typing
+	Endpoint string // Where the translation came from, see context.TranslatorFunc
+	Params   deeplapi.TranslateParams
+	Resp     *deeplapi.TranslateResp
+
+	// Languages selected when the translation was requested
+	Source *deeplapi.Language // Detected automatically if nil
+	Target deeplapi.Language
+}
+
+// Previously requested translation has been aborted by the user
+type APITranslationCancelledMsg struct {
+	Seq uint64 // Number of the request
+}
+
+// The user stopped typing while live translation is enabled.
+// ID identifies the edit after which the delay started.
+type LiveTranslateTickMsg struct {
+	ID int
+}
+
+// The source text should be translated because it changed
+type LiveTranslateTriggeredMsg struct{}
+
+// Command to trigger LiveTranslateTriggered
+func LiveTranslateTriggeredCmd() func() tea.Msg {
+	return func() tea.Msg {
+		return LiveTranslateTriggeredMsg{}
+	}
+}
+
+// Describes the action of the user enabling or disabling live translation
+type LiveTranslateToggledMsg struct {
+	Enabled bool
+}
 
-// Command to trigger APITranslationReceived
-func APITranslationReceivedCmd() func() tea.Msg {
+// Command to trigger LiveTranslateToggled
+func LiveTranslateToggledCmd(enabled bool) func() tea.Msg {
 	return func() tea.Msg {
-		return APITranslationReceivedMsg{}
+		return LiveTranslateToggledMsg{Enabled: enabled}
+	}
+}
+
+// The usage of the account has been received
+type APIUsageReceivedMsg struct {
+	Usage deeplapi.Usage
+}
+
+// Command to trigger APIUsageReceived
+func APIUsageReceivedCmd(usage deeplapi.Usage) func() tea.Msg {
+	return func() tea.Msg {
+		return APIUsageReceivedMsg{
+			Usage: usage,
+		}
+	}
+}
+
+// Describes the action of the user opening the history view
+type HistoryOpenedMsg struct{}
+
+// Command to trigger HistoryOpened
+func HistoryOpenedCmd() func() tea.Msg {
+	return func() tea.Msg {
+		return HistoryOpenedMsg{}
+	}
+}
+
+// Describes the action of the user leaving the history view
+// without selecting an entry
+type HistoryClosedMsg struct{}
+
+// Command to trigger HistoryClosed
+func HistoryClosedCmd() func() tea.Msg {
+	return func() tea.Msg {
+		return HistoryClosedMsg{}
+	}
+}
+
+// The entries of the history have been read
+type HistoryLoadedMsg struct {
+	Entries []history.Entry
+}
+
+// Command to trigger HistoryLoaded
+func HistoryLoadedCmd(entries []history.Entry) func() tea.Msg {
+	return func() tea.Msg {
+		return HistoryLoadedMsg{
+			Entries: entries,
+		}
+	}
+}
+
+// Describes the action of the user selecting a history entry
+// to be opened in the main view
+type HistoryEntrySelectedMsg struct {
+	Entry history.Entry
+}
+
+// Command to trigger HistoryEntrySelected
+func HistoryEntrySelectedCmd(entry history.Entry) func() tea.Msg {
+	return func() tea.Msg {
+		return HistoryEntrySelectedMsg{
+			Entry: entry,
+		}
 	}
 }
 
@@ -200,3 +391,41 @@ func StopLoadingCmd() func() tea.Msg {
 		return StopLoadingMsg{}
 	}
 }
+
+// Describes the action of the user opening the profile view
+type ProfilesOpenedMsg struct{}
+
+// Command to trigger ProfilesOpened
+func ProfilesOpenedCmd() func() tea.Msg {
+	return func() tea.Msg {
+		return ProfilesOpenedMsg{}
+	}
+}
+
+// Describes the action of the user leaving the profile view
+// without selecting a profile
+type ProfilesClosedMsg struct{}
+
+// Command to trigger ProfilesClosed
+func ProfilesClosedCmd() func() tea.Msg {
+	return func() tea.Msg {
+		return ProfilesClosedMsg{}
+	}
+}
+
+// The profiles to choose from have been read
+type ProfilesLoadedMsg struct {
+	Names []string
+}
+
+// Describes the action of the user switching to another profile
+type ProfileSelectedMsg struct {
+	Name string
+}
+
+// Command to trigger ProfileSelected
+func ProfileSelectedCmd(name string) func() tea.Msg {
+	return func() tea.Msg {
+		return ProfileSelectedMsg{Name: name}
+	}
+}
@@ -27,9 +27,14 @@ type Model struct {
 
 // Get a new button
 func InitialModel(ctx *context.ProgramContext) Model {
+	formality := "default"
+	if ctx.Formality != "" {
+		formality = ctx.Formality
+	}
+
 	return Model{
 		ctx: ctx,
-		btn: button.InitialModel(ctx, "Formality", "default"),
+		btn: button.InitialModel(ctx, "Formality", formality),
 	}
 }
 
@@ -45,6 +50,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case com.FormalitySelectedMsg:
 		m.btn.SetText(msg.Formality)
 
+	case com.HistoryEntrySelectedMsg:
+		// Entries of targets without formality keep the user's choice
+		if formality := msg.Entry.Formality; formality != "" {
+			m.btn.SetText(formality)
+		}
+
 	case tea.KeyMsg:
 		switch {
 		case key.Matches(msg, m.ctx.Keys.Select):
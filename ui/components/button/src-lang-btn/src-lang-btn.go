@@ -45,6 +45,21 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case com.SrcLangSelectedMsg:
 		m.btn.SetText(msg.Language.Name)
 
+	case com.DefaultLanguagesLoadedMsg:
+		if msg.Source != nil {
+			m.btn.SetText(msg.Source.Name)
+		}
+
+	case com.LanguagesSwappedMsg:
+		m.btn.SetText(msg.Source.Name)
+
+	case com.HistoryEntrySelectedMsg:
+		if src := msg.Entry.Source; src != nil {
+			m.btn.SetText(src.Name)
+		} else {
+			m.btn.SetText("auto")
+		}
+
 	case com.APITranslationReceivedMsg:
 		if res := m.ctx.TranslationResult; res != nil && len(res.Translations) > 0 {
 			//langDetected := res.Translations[0].DetectedSourceLanguage
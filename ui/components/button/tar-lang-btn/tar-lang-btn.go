@@ -45,6 +45,17 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case com.TarLangSelectedMsg:
 		m.btn.SetText(msg.Language.Name)
 
+	case com.DefaultLanguagesLoadedMsg:
+		if msg.Target != nil {
+			m.btn.SetText(msg.Target.Name)
+		}
+
+	case com.LanguagesSwappedMsg:
+		m.btn.SetText(msg.Target.Name)
+
+	case com.HistoryEntrySelectedMsg:
+		m.btn.SetText(msg.Entry.Target.Name)
+
 	case tea.KeyMsg:
 		switch {
 		case key.Matches(msg, m.ctx.Keys.Select):
@@ -29,7 +29,7 @@ type Model struct {
 func InitialModel(ctx *context.ProgramContext) Model {
 	return Model{
 		ctx: ctx,
-		btn: button.InitialModel(ctx, "", "Translate"),
+		btn: button.InitialModel(ctx, "", label(ctx.LiveTranslate)),
 	}
 }
 
@@ -41,6 +41,9 @@ func (m Model) Init() tea.Cmd {
 
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
+	case com.LiveTranslateToggledMsg:
+		m.btn.SetText(label(msg.Enabled))
+
 	case tea.KeyMsg:
 		switch {
 		case key.Matches(msg, m.ctx.Keys.Select):
@@ -76,3 +79,11 @@ func (m Model) UnsetActive() layout.LayoutModel {
 func (m Model) OnAvailWidthChange(width int) layout.LayoutModel {
 	return m
 }
+
+// Helper function to get the text of the button
+func label(live bool) string {
+	if live {
+		return "Translate (live)"
+	}
+	return "Translate"
+}
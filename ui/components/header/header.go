@@ -1,12 +1,16 @@
 // Package header provides the top bar of the application.
 // It displays the name of the app, the current version,
-// as well as the current status (loading etc.).
+// the profile and usage of the account, as well as the current status (loading etc.).
 
 package header
 
 import (
+	"fmt"
+	"strings"
+
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	deeplapi "github.com/leschuster/deepl-cli/pkg/deepl-api"
 	"github.com/leschuster/deepl-cli/ui/com"
 	"github.com/leschuster/deepl-cli/ui/context"
 )
@@ -17,8 +21,15 @@ type Model struct {
 	width       int
 	left, right string
 	loading     bool
+	usage       *deeplapi.Usage
 }
 
+// Number of cells of the usage gauge
+const gaugeWidth = 10
+
+// Share of the character limit from which on the usage is highlighted
+const usageWarningRatio = 0.9
+
 func InitialModel(ctx *context.ProgramContext) Model {
 	return Model{
 		ctx:   ctx,
@@ -39,13 +50,22 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.loading = true
 	case com.StopLoadingMsg:
 		m.loading = false
+	case com.APIUsageReceivedMsg:
+		m.usage = &msg.Usage
+	case com.ProfileSelectedMsg:
+		m.usage = nil // Belongs to the previous profile
 	}
 	return m, nil
 }
 
 func (m Model) View() string {
 	left := m.ctx.Styles.Header.LeftSide.Render(m.left)
-	right := m.ctx.Styles.Header.RightSide.Render(m.right)
+	right := lipgloss.JoinHorizontal(
+		lipgloss.Top,
+		m.viewProfile(),
+		m.viewUsage(),
+		m.ctx.Styles.Header.RightSide.Render(m.right),
+	)
 
 	middleContent := ""
 	if m.loading {
@@ -66,3 +86,60 @@ func (m Model) View() string {
 		right,
 	)
 }
+
+// Render the name of the profile in use.
+// Nothing is shown if there is only a single profile.
+func (m Model) viewProfile() string {
+	if len(m.ctx.Profiles) < 2 {
+		return ""
+	}
+
+	profile := "profile: " + m.ctx.Profile
+
+	// Keys from the command line or the environment are used by every profile
+	if source := m.ctx.KeyOverride; source != "" {
+		profile += fmt.Sprintf(" (key from %s)", source)
+	}
+
+	return m.ctx.Styles.Header.Profile.Render(profile)
+}
+
+// Render the share of the character limit that has been used up
+func (m Model) viewUsage() string {
+	if m.usage == nil || m.usage.CharacterLimit <= 0 {
+		return ""
+	}
+
+	ratio := m.usage.CharacterRatio()
+	filled := int(ratio * gaugeWidth)
+
+	gauge := fmt.Sprintf(
+		"%s / %s chars %s%s %.0f%%",
+		formatCount(m.usage.CharacterCount),
+		formatCount(m.usage.CharacterLimit),
+		strings.Repeat("█", filled),
+		strings.Repeat("░", gaugeWidth-filled),
+		ratio*100,
+	)
+
+	style := m.ctx.Styles.Header.Usage
+	if ratio >= usageWarningRatio {
+		style = m.ctx.Styles.Header.UsageWarning
+	}
+
+	return style.Render(gauge)
+}
+
+// Helper function to shorten large numbers, e.g. 500000 to 500k
+func formatCount(n int64) string {
+	switch {
+	case n >= 1_000_000_000:
+		return strings.TrimSuffix(fmt.Sprintf("%.1f", float64(n)/1_000_000_000), ".0") + "G"
+	case n >= 1_000_000:
+		return strings.TrimSuffix(fmt.Sprintf("%.1f", float64(n)/1_000_000), ".0") + "M"
+	case n >= 1_000:
+		return strings.TrimSuffix(fmt.Sprintf("%.1f", float64(n)/1_000), ".0") + "k"
+	default:
+		return fmt.Sprintf("%d", n)
+	}
+}
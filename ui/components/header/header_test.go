@@ -0,0 +1,40 @@
+package header
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/leschuster/deepl-cli/pkg/auth"
+	"github.com/leschuster/deepl-cli/ui/context"
+)
+
+// Helper function to get a header that is wide enough for all of its content
+func newTestModel(ctx *context.ProgramContext) Model {
+	model, _ := InitialModel(ctx).Update(tea.WindowSizeMsg{Width: 200, Height: 50})
+	return model.(Model)
+}
+
+func TestProfileIsOnlyShownWithSeveralProfiles(t *testing.T) {
+	ctx := context.New()
+	ctx.Profile, ctx.Profiles = "default", []string{"default"}
+
+	if got := newTestModel(ctx).View(); strings.Contains(got, "profile:") {
+		t.Errorf("got %q, want no profile with a single one", got)
+	}
+
+	ctx.Profiles = []string{"default", "team"}
+	if got := newTestModel(ctx).View(); !strings.Contains(got, "profile: default") {
+		t.Errorf("got %q, want the active profile", got)
+	}
+}
+
+func TestProfileShowsKeyOverride(t *testing.T) {
+	ctx := context.New()
+	ctx.Profile, ctx.Profiles = "team", []string{"default", "team"}
+	ctx.KeyOverride = auth.SourceEnv
+
+	if got := newTestModel(ctx).View(); !strings.Contains(got, "profile: team (key from DEEPL_AUTH_KEY)") {
+		t.Errorf("got %q, want the source of the key next to the profile", got)
+	}
+}
@@ -36,9 +36,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch {
-		case key.Matches(msg, m.ctx.Keys.ShowFullHelp):
+		// Both bindings may share the same key to toggle the help
+		case key.Matches(msg, m.ctx.Keys.ShowFullHelp) && !m.help.ShowAll:
 			m.help.ShowAll = true
-		case key.Matches(msg, m.ctx.Keys.CloseFullHelp):
+		case key.Matches(msg, m.ctx.Keys.CloseFullHelp) && m.help.ShowAll:
 			m.help.ShowAll = false
 		}
 	}
@@ -47,7 +48,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 func (m Model) View() string {
 	helpView := m.help.View(m.ctx.Keys)
-	height := m.height - strings.Count(helpView, "\n")
+	height := max(m.height-strings.Count(helpView, "\n"), 0)
 
 	return "\n" + strings.Repeat("\n", height) + helpView
 }
@@ -0,0 +1,51 @@
+package help
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/leschuster/deepl-cli/ui/context"
+	"github.com/leschuster/deepl-cli/ui/keys"
+)
+
+func press(m Model, k string) Model {
+	msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+	switch k {
+	case "f1":
+		msg = tea.KeyMsg{Type: tea.KeyF1}
+	}
+
+	model, _ := m.Update(msg)
+	return model.(Model)
+}
+
+func TestToggleFullHelp(t *testing.T) {
+	ctx := context.New()
+	m := InitialModel(ctx, 1)
+
+	m = press(m, "?")
+	if !m.help.ShowAll {
+		t.Fatal("expected full help to be shown after pressing ?")
+	}
+
+	m = press(m, "?")
+	if m.help.ShowAll {
+		t.Fatal("expected full help to be closed after pressing ? again")
+	}
+}
+
+func TestToggleFullHelpEmacs(t *testing.T) {
+	ctx := context.New()
+	ctx.Keys = keys.EmacsKeyMap()
+	m := InitialModel(ctx, 1)
+
+	m = press(m, "f1")
+	if !m.help.ShowAll {
+		t.Fatal("expected full help to be shown after pressing f1")
+	}
+
+	m = press(m, "f1")
+	if m.help.ShowAll {
+		t.Fatal("expected full help to be closed after pressing f1 again")
+	}
+}
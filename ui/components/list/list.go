@@ -124,3 +124,13 @@ func (m *Model[T]) SetItems(items []Item[T]) {
 	}
 	m.list.SetItems(i)
 }
+
+// Check whether the user is currently typing a filter
+func (m *Model[T]) IsFiltering() bool {
+	return m.list.FilterState() == list.Filtering
+}
+
+// Check whether a filter is applied or being typed
+func (m *Model[T]) IsFiltered() bool {
+	return m.list.FilterState() != list.Unfiltered
+}
@@ -33,7 +33,7 @@ func (m Model) Init() tea.Cmd {
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg.(type) {
 	case com.ContentSizeMsg:
-		m.height = m.ctx.ContentHeight - 10
+		m.height = m.ctx.TextareaHeight()
 	}
 	return m, nil
 }
@@ -0,0 +1,153 @@
+// Package ctxtextarea provides the collapsible text area for the user to type in
+// additional context that influences the translation, but is not translated itself.
+
+package ctxtextarea
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/leschuster/deepl-cli/ui/com"
+	"github.com/leschuster/deepl-cli/ui/components/layout"
+	"github.com/leschuster/deepl-cli/ui/components/textarea"
+	"github.com/leschuster/deepl-cli/ui/context"
+)
+
+/*
+ * This is just a tight wrapper around the base textarea component.
+ * We need to to this in order to listen to different messages
+ * and output different commands. With the Layout package used,
+ * we do not have outside access to the Model instances.
+ */
+
+// Provides the textarea where the user can type the context of the translation.
+// While collapsed, it only shows a preview of its content.
+type Model struct {
+	ctx        *context.ProgramContext
+	textarea   textarea.Model
+	insertMode bool
+	width      int
+}
+
+func InitialModel(ctx *context.ProgramContext) Model {
+	ta := textarea.InitialModel(ctx, "E.g. \"Button label in a file menu\"")
+	ta.SetFixedHeight(context.ContextFieldLines)
+	ta.SetStyles(lipgloss.NewStyle(), lipgloss.NewStyle()) // Styled by this component
+
+	return Model{
+		ctx:      ctx,
+		textarea: ta,
+	}
+}
+
+// Implement tea.Model interface
+
+func (m Model) Init() tea.Cmd {
+	return m.textarea.Init()
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	var cmds []tea.Cmd
+
+	switch msg := msg.(type) {
+
+	case tea.KeyMsg:
+		switch {
+
+		// User can start to type after entering insert mode
+		// The field expands if it was collapsed
+		case key.Matches(msg, m.ctx.Keys.Select) && m.textarea.IsActive() && !m.insertMode:
+			if !m.ctx.ContextExpanded {
+				m.ctx.ContextExpanded = true
+				cmds = append(cmds, com.ContentSizeCmd()) // Textareas need to shrink
+			}
+
+			m.textarea.Focus()
+			m.insertMode = true
+			cmds = append(cmds, com.InsertModeEnteredCmd())
+
+			// Return early because the textarea shall
+			// not receive the 'enter' key that activated insert mode
+			return m, tea.Batch(cmds...)
+
+		// User can no longer type after exiting insert mode
+		// Saving the users text
+		case key.Matches(msg, m.ctx.Keys.Unselect) && m.insertMode:
+			m.textarea.Blur()
+			m.insertMode = false
+			m.ctx.ContextText = m.textarea.Value()
+			cmds = append(cmds, com.InsertModeExitedCmd())
+
+		// Collapse the field if the user is not typing
+		case key.Matches(msg, m.ctx.Keys.Unselect) && m.ctx.ContextExpanded:
+			m.ctx.ContextExpanded = false
+			cmds = append(cmds, com.ContentSizeCmd()) // Textareas may grow again
+		}
+
+	}
+
+	ta, cmd := m.textarea.Update(msg)
+	m.textarea = ta.(textarea.Model)
+	cmds = append(cmds, cmd)
+
+	return m, tea.Batch(cmds...)
+}
+
+func (m Model) View() string {
+	style := m.ctx.Styles.ContextTextarea.Style
+	if m.textarea.IsActive() {
+		style = m.ctx.Styles.ContextTextarea.ActiveStyle
+	}
+	header := m.ctx.Styles.ContextTextarea.Header
+
+	if !m.ctx.ContextExpanded {
+		return style.Render(header.Render("▸ Context: ") + m.preview())
+	}
+
+	return style.Render(lipgloss.JoinVertical(
+		lipgloss.Left,
+		header.Render("▾ Context:"),
+		m.textarea.View(),
+	))
+}
+
+// Helper function to get a single line summary of the context
+func (m Model) preview() string {
+	text := strings.Join(strings.Fields(m.ctx.ContextText), " ")
+	if text == "" {
+		return "none"
+	}
+
+	maxWidth := m.width - lipgloss.Width("▸ Context: ") - 4
+	if maxWidth > 1 && lipgloss.Width(text) > maxWidth {
+		runes := []rune(text)
+		text = string(runes[:min(len(runes), maxWidth-1)]) + "…"
+	}
+
+	return text
+}
+
+// Implement layout.LayoutModel interface
+
+func (m Model) IsActive() bool {
+	return m.textarea.IsActive()
+}
+func (m Model) SetActive() layout.LayoutModel {
+	mod := m.textarea.SetActive()
+	m.textarea = mod.(textarea.Model)
+	return m
+}
+func (m Model) UnsetActive() layout.LayoutModel {
+	mod := m.textarea.UnsetActive()
+	m.textarea = mod.(textarea.Model)
+	return m
+}
+func (m Model) OnAvailWidthChange(width int) layout.LayoutModel {
+	m.width = width
+	mod := m.textarea.OnAvailWidthChange(width)
+	m.textarea = mod.(textarea.Model)
+	return m
+}
@@ -4,12 +4,15 @@
 package srctextarea
 
 import (
+	"time"
+
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/leschuster/deepl-cli/ui/com"
 	"github.com/leschuster/deepl-cli/ui/components/layout"
 	"github.com/leschuster/deepl-cli/ui/components/textarea"
 	"github.com/leschuster/deepl-cli/ui/context"
+	"github.com/leschuster/deepl-cli/ui/utils"
 )
 
 /*
@@ -25,8 +28,12 @@ type Model struct {
 	ctx        *context.ProgramContext
 	textarea   textarea.Model
 	insertMode bool
+	edits      int // Number of edits, used to debounce live translation
 }
 
+// Time to wait after the last keystroke before translating in live mode
+const liveTranslateDelay = 600 * time.Millisecond
+
 func InitialModel(ctx *context.ProgramContext) Model {
 	return Model{
 		ctx:      ctx,
@@ -46,9 +53,34 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 
+	// Restore a past translation
+	case com.HistoryEntrySelectedMsg:
+		m.textarea.SetValue(msg.Entry.SourceText)
+
+	// The translation becomes the new source text
+	case com.LanguagesSwappedMsg:
+		m.textarea.SetValue(msg.SourceText)
+
+	// Insert the clipboard content at the cursor
+	case com.ClipboardPastedMsg:
+		m.textarea.InsertString(msg.Text)
+		m.ctx.SourceText = m.textarea.Value()
+
+	// Translate if the user stopped typing
+	case com.LiveTranslateTickMsg:
+		if msg.ID == m.edits {
+			return m, com.LiveTranslateTriggeredCmd()
+		}
+		return m, nil
+
 	case tea.KeyMsg:
 		switch {
 
+		// Paste without entering insert mode.
+		// In insert mode, the textarea handles ctrl+v itself.
+		case key.Matches(msg, m.ctx.Keys.Paste) && m.textarea.IsActive() && !m.insertMode:
+			return m, utils.PasteFromClipboard()
+
 		// User can start to type after entering insert mode
 		case key.Matches(msg, m.ctx.Keys.Select) && m.textarea.IsActive() && !m.insertMode:
 			m.textarea.Focus()
@@ -70,10 +102,21 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	}
 
+	prev := m.textarea.Value()
 	ta, cmd := m.textarea.Update(msg)
 	m.textarea = ta.(textarea.Model)
 	cmds = append(cmds, cmd)
 
+	// Translate after a short delay if the text changed
+	if m.insertMode && m.ctx.LiveTranslate && m.textarea.Value() != prev {
+		m.ctx.SourceText = m.textarea.Value()
+		m.edits++
+		id := m.edits
+		cmds = append(cmds, tea.Tick(liveTranslateDelay, func(time.Time) tea.Msg {
+			return com.LiveTranslateTickMsg{ID: id}
+		}))
+	}
+
 	return m, tea.Batch(cmds...)
 }
 
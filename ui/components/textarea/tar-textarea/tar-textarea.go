@@ -44,6 +44,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 
+	// Restore a past translation
+	case com.HistoryEntrySelectedMsg:
+		m.textarea.SetValue(msg.Entry.Result)
+
+	// The source text becomes the new translation
+	case com.LanguagesSwappedMsg:
+		m.textarea.SetValue(msg.TargetText)
+
 	// Received translation
 	case com.APITranslationReceivedMsg:
 		if res := m.ctx.TranslationResult; res != nil && len(res.Translations) > 0 {
@@ -5,6 +5,7 @@ package textarea
 import (
 	"github.com/charmbracelet/bubbles/textarea"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	"github.com/leschuster/deepl-cli/ui/com"
 	"github.com/leschuster/deepl-cli/ui/components/layout"
 	"github.com/leschuster/deepl-cli/ui/context"
@@ -12,9 +13,11 @@ import (
 
 // Textarea model
 type Model struct {
-	ctx      *context.ProgramContext
-	textarea textarea.Model
-	active   bool
+	ctx                *context.ProgramContext
+	textarea           textarea.Model
+	active             bool
+	fixedHeight        int // if 0, the height is derived from the content height
+	style, activeStyle lipgloss.Style
 }
 
 // Get new textarea
@@ -25,8 +28,10 @@ func InitialModel(ctx *context.ProgramContext, placeholder string) Model {
 	ti.Blur()
 
 	return Model{
-		ctx:      ctx,
-		textarea: ti,
+		ctx:         ctx,
+		textarea:    ti,
+		style:       ctx.Styles.Textarea.Style,
+		activeStyle: ctx.Styles.Textarea.ActiveStyle,
 	}
 }
 
@@ -42,7 +47,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch msg.(type) {
 	case com.ContentSizeMsg:
-		textareaHeight := m.ctx.ContentHeight - 10
+		textareaHeight := m.ctx.TextareaHeight()
+		if m.fixedHeight > 0 {
+			textareaHeight = m.fixedHeight
+		}
 		m.textarea.SetHeight(textareaHeight)
 	}
 
@@ -53,10 +61,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 // Render textarea
 func (m Model) View() string {
-	fn := m.ctx.Styles.Textarea.Style.Render
+	fn := m.style.Render
 
 	if m.active {
-		fn = m.ctx.Styles.Textarea.ActiveStyle.Render
+		fn = m.activeStyle.Render
 	}
 
 	return fn(m.textarea.View())
@@ -99,3 +107,19 @@ func (m *Model) Value() string {
 func (m *Model) SetValue(text string) {
 	m.textarea.SetValue(text)
 }
+
+// Insert text at the cursor position
+func (m *Model) InsertString(text string) {
+	m.textarea.InsertString(text)
+}
+
+// Use a fixed number of lines instead of the height available in the main view
+func (m *Model) SetFixedHeight(height int) {
+	m.fixedHeight = height
+	m.textarea.SetHeight(height)
+}
+
+// Replace the default textarea styles
+func (m *Model) SetStyles(style, activeStyle lipgloss.Style) {
+	m.style, m.activeStyle = style, activeStyle
+}
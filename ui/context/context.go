@@ -4,24 +4,53 @@
 package context
 
 import (
+	"github.com/leschuster/deepl-cli/pkg/auth"
+	"github.com/leschuster/deepl-cli/pkg/cache"
 	deeplapi "github.com/leschuster/deepl-cli/pkg/deepl-api"
+	"github.com/leschuster/deepl-cli/pkg/history"
 	"github.com/leschuster/deepl-cli/ui/keys"
 	"github.com/leschuster/deepl-cli/ui/styles"
 	"github.com/leschuster/deepl-cli/ui/utils"
 )
 
+// Number of lines of the expanded context field in the main view
+const ContextFieldLines = 3
+
+// Height of the context field in the main view, including header and margin
+const (
+	contextFieldCollapsedHeight = 2
+	contextFieldExpandedHeight  = ContextFieldLines + 2
+)
+
+// TranslatorFunc creates the translator for an API key.
+// The endpoint names where it translates, e.g. the base URL of the DeepL API,
+// and keeps cached translations of different endpoints apart.
+type TranslatorFunc func(apiKey string) (translator deeplapi.Translator, endpoint string)
+
 type ProgramContext struct {
-	Api                            *deeplapi.DeeplAPI
+	Api                            deeplapi.Translator // Translates the source text, nil if the user is not signed in
+	Endpoint                       string              // Where Api translates, see TranslatorFunc
+	NewTranslator                  TranslatorFunc      // Creates Api for an API key
+	Cache                          *cache.Cache        // Translations that do not need to be requested again, nil if disabled
+	History                        *history.Store      // Past translations, nil if disabled
+	Profile                        string              // Name of the profile whose API key is used
+	Profiles                       []string            // Names of all profiles
+	KeyOverride                    auth.Source         // Where an API key is provided that takes precedence over a saved one, empty if nowhere
 	Keys                           keys.KeyMap
 	ScreenWidth, ScreenHeight      int // Size of entire screen
 	ContentWidth, ContentHeight    int // Size of the space that is available to a view
 	Styles                         *styles.Styles
 	SourceLanguage, TargetLanguage *deeplapi.Language
 	SourceText                     string
+	ContextText                    string // Additional context that influences the translation, but is not translated itself
+	ContextExpanded                bool   // Whether the context field in the main view is expanded
 	Formality                      string
+	TagHandling                    string // Kind of markup in SourceText, empty for plain text
 	TranslationResult              *deeplapi.TranslateResp
 	AvailableLanguages             utils.AvailableLanguages
 	InsertMode                     bool
+	LiveTranslate                  bool   // Whether the source text is translated while typing
+	CancelTranslation              func() // Aborts the translation in flight, nil if there is none
 }
 
 func New() *ProgramContext {
@@ -31,3 +60,18 @@ func New() *ProgramContext {
 		AvailableLanguages: utils.NewAvailableLanguages(),
 	}
 }
+
+// Get the height available to the source and target textareas in the main view
+func (ctx *ProgramContext) TextareaHeight() int {
+	contextFieldHeight := contextFieldCollapsedHeight
+	if ctx.ContextExpanded {
+		contextFieldHeight = contextFieldExpandedHeight
+	}
+
+	return max(ctx.ContentHeight-10-contextFieldHeight, 1)
+}
+
+// Translate with the translator for an API key, see NewTranslator
+func (ctx *ProgramContext) UseAPIKey(apiKey string) {
+	ctx.Api, ctx.Endpoint = ctx.NewTranslator(apiKey)
+}
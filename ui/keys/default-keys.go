@@ -67,6 +67,42 @@ func DefaultKeyMap() KeyMap {
 			key.WithHelp("enter", "apply filter"),
 		),
 
+		// Main view.
+		History: key.NewBinding(
+			key.WithKeys("ctrl+r"),
+			key.WithHelp("ctrl+r", "history"),
+		),
+		Swap: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", "swap languages"),
+		),
+		Copy: key.NewBinding(
+			key.WithKeys("y"),
+			key.WithHelp("y", "copy translation"),
+		),
+		Paste: key.NewBinding(
+			key.WithKeys("p"),
+			key.WithHelp("p", "paste"),
+		),
+		LiveTranslate: key.NewBinding(
+			key.WithKeys("a"),
+			key.WithHelp("a", "toggle live translation"),
+		),
+		Profiles: key.NewBinding(
+			key.WithKeys("P"),
+			key.WithHelp("P", "switch profile"),
+		),
+		ChangeAPIKey: key.NewBinding(
+			key.WithKeys("K"),
+			key.WithHelp("K", "change API key"),
+		),
+
+		// History view.
+		Delete: key.NewBinding(
+			key.WithKeys("x", "delete"),
+			key.WithHelp("x/del", "delete entry"),
+		),
+
 		// Toggle help.
 		ShowFullHelp: key.NewBinding(
 			key.WithKeys("?"),
@@ -0,0 +1,125 @@
+package keys
+
+import "github.com/charmbracelet/bubbles/key"
+
+// Keybindings for users used to emacs.
+// No plain letters are bound, so typing never triggers an action.
+func EmacsKeyMap() KeyMap {
+	return KeyMap{
+		Select: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "select"),
+		),
+		Unselect: key.NewBinding(
+			key.WithKeys("esc", "ctrl+g"),
+			key.WithHelp("esc/C-g", "unselect"),
+		),
+
+		// Navigation
+		Up: key.NewBinding(
+			key.WithKeys("up", "ctrl+p"),
+			key.WithHelp("↑/C-p", "up"),
+		),
+		Right: key.NewBinding(
+			key.WithKeys("right", "ctrl+f"),
+			key.WithHelp("→/C-f", "right"),
+		),
+		Down: key.NewBinding(
+			key.WithKeys("down", "ctrl+n"),
+			key.WithHelp("↓/C-n", "down"),
+		),
+		Left: key.NewBinding(
+			key.WithKeys("left", "ctrl+b"),
+			key.WithHelp("←/C-b", "left"),
+		),
+
+		// Browsing.
+		PrevPage: key.NewBinding(
+			key.WithKeys("pgup", "alt+v"),
+			key.WithHelp("pgup/M-v", "prev page"),
+		),
+		NextPage: key.NewBinding(
+			key.WithKeys("pgdown", "ctrl+v"),
+			key.WithHelp("pgdn/C-v", "next page"),
+		),
+		GoToStart: key.NewBinding(
+			key.WithKeys("home", "alt+<"),
+			key.WithHelp("M-</home", "go to start"),
+		),
+		GoToEnd: key.NewBinding(
+			key.WithKeys("end", "alt+>"),
+			key.WithHelp("M->/end", "go to end"),
+		),
+		Filter: key.NewBinding(
+			key.WithKeys("ctrl+s"),
+			key.WithHelp("C-s", "filter"),
+		),
+		ClearFilter: key.NewBinding(
+			key.WithKeys("esc", "ctrl+g"),
+			key.WithHelp("esc/C-g", "clear filter"),
+		),
+
+		// Filtering.
+		CancelWhileFiltering: key.NewBinding(
+			key.WithKeys("esc", "ctrl+g"),
+			key.WithHelp("esc/C-g", "cancel"),
+		),
+		AcceptWhileFiltering: key.NewBinding(
+			key.WithKeys("enter", "tab", "shift+tab", "up", "ctrl+p", "down", "ctrl+n"),
+			key.WithHelp("enter", "apply filter"),
+		),
+
+		// Main view.
+		History: key.NewBinding(
+			key.WithKeys("ctrl+r"),
+			key.WithHelp("C-r", "history"),
+		),
+		Swap: key.NewBinding(
+			key.WithKeys("alt+s"),
+			key.WithHelp("M-s", "swap languages"),
+		),
+		Copy: key.NewBinding(
+			key.WithKeys("alt+w"),
+			key.WithHelp("M-w", "copy translation"),
+		),
+		Paste: key.NewBinding(
+			key.WithKeys("ctrl+y"),
+			key.WithHelp("C-y", "paste"),
+		),
+		LiveTranslate: key.NewBinding(
+			key.WithKeys("alt+l"),
+			key.WithHelp("M-l", "toggle live translation"),
+		),
+		Profiles: key.NewBinding(
+			key.WithKeys("alt+p"),
+			key.WithHelp("M-p", "switch profile"),
+		),
+		ChangeAPIKey: key.NewBinding(
+			key.WithKeys("alt+k"),
+			key.WithHelp("M-k", "change API key"),
+		),
+
+		// History view.
+		Delete: key.NewBinding(
+			key.WithKeys("ctrl+d", "delete"),
+			key.WithHelp("C-d/del", "delete entry"),
+		),
+
+		// Toggle help.
+		ShowFullHelp: key.NewBinding(
+			key.WithKeys("f1"),
+			key.WithHelp("f1", "more"),
+		),
+		CloseFullHelp: key.NewBinding(
+			key.WithKeys("f1"),
+			key.WithHelp("f1", "close help"),
+		),
+
+		// Quitting.
+		Quit: key.NewBinding(
+			key.WithKeys("ctrl+x"),
+			key.WithHelp("C-x", "quit"),
+		),
+		ForceQuit: key.NewBinding(key.WithKeys("ctrl+c")),
+	}
+}
@@ -4,6 +4,10 @@
 package keys
 
 import (
+	"fmt"
+	"slices"
+	"strings"
+
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
 )
@@ -32,6 +36,19 @@ type KeyMap struct {
 	CancelWhileFiltering key.Binding
 	AcceptWhileFiltering key.Binding
 
+	// Keybindings used in the main view.
+	History key.Binding
+	Swap    key.Binding
+	Copy    key.Binding
+	Paste   key.Binding
+
+	LiveTranslate key.Binding
+	Profiles      key.Binding
+	ChangeAPIKey  key.Binding
+
+	// Keybindings used in the history view.
+	Delete key.Binding
+
 	// Help toggle keybindings.
 	ShowFullHelp  key.Binding
 	CloseFullHelp key.Binding
@@ -54,6 +71,8 @@ func (k KeyMap) FullHelp() [][]key.Binding {
 		{k.Select, k.Unselect, k.CloseFullHelp, k.Quit},
 		{k.Up, k.Down, k.Right, k.Left},
 		{k.NextPage, k.PrevPage, k.Filter, k.ClearFilter},
+		{k.Swap, k.Copy, k.Paste, k.LiveTranslate},
+		{k.History, k.Profiles, k.ChangeAPIKey, k.Delete},
 	}
 }
 
@@ -75,3 +94,144 @@ func (k *KeyMap) ConvertToListKeyMap() list.KeyMap {
 		ForceQuit:            k.ForceQuit,
 	}
 }
+
+// Get all keybindings that can be customized, by their name
+func (k *KeyMap) Bindings() map[string]*key.Binding {
+	return map[string]*key.Binding{
+		"select":                 &k.Select,
+		"unselect":               &k.Unselect,
+		"up":                     &k.Up,
+		"right":                  &k.Right,
+		"down":                   &k.Down,
+		"left":                   &k.Left,
+		"next_page":              &k.NextPage,
+		"prev_page":              &k.PrevPage,
+		"go_to_start":            &k.GoToStart,
+		"go_to_end":              &k.GoToEnd,
+		"filter":                 &k.Filter,
+		"clear_filter":           &k.ClearFilter,
+		"cancel_while_filtering": &k.CancelWhileFiltering,
+		"accept_while_filtering": &k.AcceptWhileFiltering,
+		"history":                &k.History,
+		"swap":                   &k.Swap,
+		"copy":                   &k.Copy,
+		"paste":                  &k.Paste,
+		"live_translate":         &k.LiveTranslate,
+		"profiles":               &k.Profiles,
+		"change_api_key":         &k.ChangeAPIKey,
+		"delete":                 &k.Delete,
+		"show_full_help":         &k.ShowFullHelp,
+		"close_full_help":        &k.CloseFullHelp,
+		"quit":                   &k.Quit,
+		"force_quit":             &k.ForceQuit,
+	}
+}
+
+// Replace the keys of keybindings, e.g. {"swap": {"ctrl+s"}}.
+// The help text is updated accordingly.
+func (k *KeyMap) Apply(custom map[string][]string) error {
+	bindings := k.Bindings()
+
+	for name, keys := range custom {
+		binding, ok := bindings[name]
+		if !ok {
+			return fmt.Errorf("unknown keybinding '%s'", name)
+		}
+		if len(keys) == 0 {
+			return fmt.Errorf("no keys given for keybinding '%s'", name)
+		}
+
+		binding.SetKeys(keys...)
+		binding.SetHelp(strings.Join(keys, "/"), binding.Help().Desc)
+	}
+
+	return nil
+}
+
+// Bindings that are active at the same time and must not share keys.
+// Global bindings are active everywhere.
+var (
+	globalBindings = []string{"show_full_help", "close_full_help", "quit", "force_quit"}
+	scopes         = []struct {
+		name     string
+		bindings []string
+	}{
+		{"main view", []string{"select", "unselect", "up", "right", "down", "left", "history", "swap", "copy", "paste", "live_translate", "profiles", "change_api_key"}},
+		{"lists", []string{"select", "unselect", "up", "down", "next_page", "prev_page", "go_to_start", "go_to_end", "filter", "clear_filter", "delete"}},
+		{"filter", []string{"cancel_while_filtering", "accept_while_filtering"}},
+	}
+
+	// Pairs of bindings that share keys on purpose
+	sharedBindings = [][2]string{
+		{"show_full_help", "close_full_help"}, // Toggles the help
+		{"unselect", "clear_filter"},          // Clears the filter first, then leaves the list
+	}
+)
+
+// Check that no key triggers two different actions at the same time
+func (k *KeyMap) Validate() error {
+	bindings := k.Bindings()
+
+	for _, scope := range scopes {
+		owners := map[string]string{} // Key to name of binding
+
+		for _, name := range append(slices.Clone(scope.bindings), globalBindings...) {
+			for _, keyName := range bindings[name].Keys() {
+				owner, ok := owners[keyName]
+				if ok && owner != name && !isShared(owner, name) {
+					return fmt.Errorf("key '%s' is bound to both %s and %s in %s", keyName, owner, name, scope.name)
+				}
+				owners[keyName] = name
+			}
+		}
+	}
+
+	return nil
+}
+
+// Helper function to check whether two bindings may share keys
+func isShared(a, b string) bool {
+	for _, pair := range sharedBindings {
+		if (pair[0] == a && pair[1] == b) || (pair[0] == b && pair[1] == a) {
+			return true
+		}
+	}
+	return false
+}
+
+// Names of the available presets
+const (
+	PresetDefault = "default" // vim-style
+	PresetEmacs   = "emacs"
+)
+
+// Get the keymap of a preset, an empty name is the default preset
+func Preset(name string) (KeyMap, error) {
+	switch name {
+	case "", PresetDefault:
+		return DefaultKeyMap(), nil
+	case PresetEmacs:
+		return EmacsKeyMap(), nil
+	}
+
+	return KeyMap{}, fmt.Errorf("unknown keymap '%s', use %s or %s", name, PresetDefault, PresetEmacs)
+}
+
+// Get the keymap of a preset with custom keys applied, see Apply.
+// Fails if keys conflict.
+func Load(preset string, custom map[string][]string) (KeyMap, error) {
+	k, err := Preset(preset)
+	if err != nil {
+		return KeyMap{}, err
+	}
+
+	if err := k.Apply(custom); err != nil {
+		return KeyMap{}, err
+	}
+
+	if err := k.Validate(); err != nil {
+		return KeyMap{}, err
+	}
+
+	return k, nil
+}
@@ -0,0 +1,75 @@
+package keys
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestPresetsAreValid(t *testing.T) {
+	for _, name := range []string{PresetDefault, PresetEmacs} {
+		k, err := Preset(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := k.Validate(); err != nil {
+			t.Errorf("preset %s: %v", name, err)
+		}
+	}
+}
+
+func TestLoadAppliesCustomKeys(t *testing.T) {
+	k, err := Load(PresetDefault, map[string][]string{"swap": {"ctrl+s", "S"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := k.Swap.Keys(); !slices.Equal(got, []string{"ctrl+s", "S"}) {
+		t.Errorf("got keys %v, want [ctrl+s S]", got)
+	}
+	if got := k.Swap.Help(); got.Key != "ctrl+s/S" || got.Desc != "swap languages" {
+		t.Errorf("got help %+v, want ctrl+s/S with the original description", got)
+	}
+}
+
+func TestLoadRejectsConflicts(t *testing.T) {
+	// Both are active in the main view
+	_, err := Load(PresetDefault, map[string][]string{"swap": {"y"}})
+	if err == nil || !strings.Contains(err.Error(), "main view") {
+		t.Errorf("got %v, want a conflict in the main view", err)
+	}
+
+	// Global bindings conflict with every scope
+	if _, err := Load(PresetDefault, map[string][]string{"delete": {"q"}}); err == nil {
+		t.Error("expected a conflict with quit")
+	}
+}
+
+func TestLoadAllowsKeysInSeparateScopes(t *testing.T) {
+	// Swap is only active in the main view, delete only in lists
+	if _, err := Load(PresetDefault, map[string][]string{"delete": {"s"}}); err != nil {
+		t.Errorf("got %v, want no conflict", err)
+	}
+
+	// Showing and closing the help share a key on purpose
+	if _, err := Load(PresetDefault, map[string][]string{"show_full_help": {"H"}, "close_full_help": {"H"}}); err != nil {
+		t.Errorf("got %v, want no conflict", err)
+	}
+}
+
+func TestLoadRejectsInvalidInput(t *testing.T) {
+	tests := []struct {
+		preset string
+		custom map[string][]string
+	}{
+		{"vi", nil},
+		{PresetDefault, map[string][]string{"unknown": {"u"}}},
+		{PresetDefault, map[string][]string{"swap": {}}},
+	}
+
+	for _, tt := range tests {
+		if _, err := Load(tt.preset, tt.custom); err == nil {
+			t.Errorf("Load(%q, %v) succeeded, want an error", tt.preset, tt.custom)
+		}
+	}
+}
@@ -10,17 +10,7 @@ import (
 type Styles struct {
 	// COLORS
 
-	Colors struct {
-		Primary struct {
-			Foreground lipgloss.AdaptiveColor
-			Background lipgloss.AdaptiveColor
-		}
-		Active struct {
-			Foreground lipgloss.AdaptiveColor
-			Background lipgloss.AdaptiveColor
-		}
-		Error lipgloss.AdaptiveColor
-	}
+	Colors Colors
 
 	// VIEWS
 
@@ -34,6 +24,7 @@ type Styles struct {
 
 	LoginView struct {
 		Style lipgloss.Style
+		Error lipgloss.Style
 	}
 
 	ErrorView struct {
@@ -45,6 +36,8 @@ type Styles struct {
 	Header struct {
 		Style                       lipgloss.Style
 		LeftSide, RightSide, Spacer lipgloss.Style
+		Usage, UsageWarning         lipgloss.Style
+		Profile                     lipgloss.Style
 	}
 
 	Textarea struct {
@@ -52,6 +45,12 @@ type Styles struct {
 		ActiveStyle lipgloss.Style
 	}
 
+	ContextTextarea struct {
+		Style       lipgloss.Style
+		ActiveStyle lipgloss.Style
+		Header      lipgloss.Style
+	}
+
 	TextareaDelimiter struct {
 		Style lipgloss.Style
 	}
@@ -68,18 +67,30 @@ type Styles struct {
 	}
 }
 
+// Get the styles of the default theme
 func New() *Styles {
+	return NewWithTheme(DefaultTheme())
+}
+
+// Get the styles of a theme
+func NewWithTheme(t Theme) *Styles {
 	s := Styles{}
 
 	// COLORS
 
-	s.Colors.Primary.Foreground = lipgloss.AdaptiveColor{Light: "15", Dark: "15"}
-	s.Colors.Primary.Background = lipgloss.AdaptiveColor{Light: "56", Dark: "56"}
+	s.Colors = t.Colors
 
-	s.Colors.Active.Foreground = lipgloss.AdaptiveColor{Light: "12", Dark: "12"}
-	s.Colors.Active.Background = lipgloss.AdaptiveColor{Light: "200", Dark: "200"}
+	// Without colors, active elements are marked by borders and inverted text instead
+	activeTextareaBorder := lipgloss.HiddenBorder()
+	if t.NoColor {
+		activeTextareaBorder = lipgloss.NormalBorder()
+	}
 
-	s.Colors.Error = lipgloss.AdaptiveColor{Light: "9", Dark: "9"}
+	// Borders of the views, only replaced if the theme defines one
+	langViewBorder, dialogBorder := lipgloss.NormalBorder(), lipgloss.RoundedBorder()
+	if t.Border != (lipgloss.Border{}) {
+		langViewBorder, dialogBorder = t.Border, t.Border
+	}
 
 	// VIEWS
 
@@ -88,16 +99,20 @@ func New() *Styles {
 
 	s.LangView.Style = lipgloss.NewStyle().
 		Padding(1, 2).
-		Border(lipgloss.NormalBorder())
+		Border(langViewBorder)
 		//BorderBackground(lipgloss.Color("62"))
 
 	s.LoginView.Style = lipgloss.NewStyle().
 		Padding(1, 2).
-		Border(lipgloss.RoundedBorder())
+		Border(dialogBorder)
+
+	s.LoginView.Error = lipgloss.NewStyle().
+		Foreground(s.Colors.Error).
+		Bold(t.NoColor || t.Bold)
 
 	s.ErrorView.Style = lipgloss.NewStyle().
 		Padding(1, 2).
-		Border(lipgloss.RoundedBorder()).
+		Border(dialogBorder).
 		Foreground(s.Colors.Error)
 
 	// COMPONENTS
@@ -108,23 +123,45 @@ func New() *Styles {
 		Align(lipgloss.Left).
 		Padding(0, 1).
 		Background(s.Colors.Primary.Background).
-		Foreground(s.Colors.Primary.Foreground)
+		Foreground(s.Colors.Primary.Foreground).
+		Reverse(t.NoColor)
 	s.Header.RightSide = lipgloss.NewStyle().
 		Align(lipgloss.Right).
 		Padding(0, 1).
 		Background(s.Colors.Primary.Background).
-		Foreground(s.Colors.Primary.Foreground)
+		Foreground(s.Colors.Primary.Foreground).
+		Reverse(t.NoColor)
 	s.Header.Spacer = lipgloss.NewStyle()
+	s.Header.Usage = lipgloss.NewStyle().
+		Padding(0, 1)
+	s.Header.Profile = lipgloss.NewStyle().
+		Padding(0, 1).
+		Bold(true)
+	s.Header.UsageWarning = lipgloss.NewStyle().
+		Foreground(s.Colors.Error).
+		Bold(t.NoColor || t.Bold).
+		Inherit(s.Header.Usage)
 
 	s.Textarea.Style = lipgloss.NewStyle().
 		Margin(2, 0).
 		Padding(0, 0, 0, 1)
 	s.Textarea.ActiveStyle = lipgloss.NewStyle().
-		Border(lipgloss.HiddenBorder(), false, false, false, true).
+		Border(activeTextareaBorder, false, false, false, true).
 		BorderBackground(s.Colors.Active.Background).
 		Inherit(s.Textarea.Style).
 		Margin(2, 0)
 
+	s.ContextTextarea.Style = lipgloss.NewStyle().
+		MarginBottom(1).
+		Padding(0, 0, 0, 1)
+	s.ContextTextarea.ActiveStyle = lipgloss.NewStyle().
+		Border(activeTextareaBorder, false, false, false, true).
+		BorderBackground(s.Colors.Active.Background).
+		Inherit(s.ContextTextarea.Style).
+		MarginBottom(1)
+	s.ContextTextarea.Header = lipgloss.NewStyle().
+		Faint(true)
+
 	s.TextareaDelimiter.Style = lipgloss.NewStyle().
 		Margin(2, 0)
 
@@ -133,6 +170,7 @@ func New() *Styles {
 	s.List.Style.Title = lipgloss.NewStyle().
 		Foreground(s.Colors.Primary.Foreground).
 		Background(s.Colors.Primary.Background).
+		Reverse(t.NoColor).
 		Padding(0, 2)
 	s.List.NormalTitleStyle = lipgloss.NewStyle().
 		Padding(0, 0, 0, 2)
@@ -140,17 +178,21 @@ func New() *Styles {
 		Border(lipgloss.Border{Left: ">"}, false, false, false, true).
 		BorderForeground(s.Colors.Active.Background).
 		Foreground(s.Colors.Active.Background).
+		Bold(t.NoColor || t.Bold).
 		Padding(0, 0, 0, 1)
 
 	s.Button.Style = lipgloss.NewStyle().
 		Padding(0, 2).
 		Foreground(s.Colors.Primary.Foreground).
-		Background(s.Colors.Primary.Background)
+		Background(s.Colors.Primary.Background).
+		Reverse(t.NoColor)
 	s.Button.ActiveStyle = lipgloss.NewStyle().
 		Padding(0, 1).
 		Border(lipgloss.Border{Left: ">", Right: "<"}, false, true).
 		Foreground(s.Colors.Active.Foreground).
 		Background(s.Colors.Active.Background).
+		Bold(t.NoColor || t.Bold).
+		Reverse(false).
 		Inherit(s.Button.Style)
 
 	return &s
@@ -0,0 +1,207 @@
+package styles
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Names of the available themes
+const (
+	PresetDefault      = "default"
+	PresetLight        = "light"
+	PresetDark         = "dark"
+	PresetHighContrast = "high-contrast"
+	PresetNoColor      = "no-color"
+)
+
+// Colors used throughout the application
+type Colors struct {
+	Primary struct {
+		Foreground lipgloss.TerminalColor
+		Background lipgloss.TerminalColor
+	}
+	Active struct {
+		Foreground lipgloss.TerminalColor
+		Background lipgloss.TerminalColor
+	}
+	Error lipgloss.TerminalColor
+}
+
+// Theme defines the look of the application
+type Theme struct {
+	Colors  Colors
+	Border  lipgloss.Border // Border of views, the default borders if empty
+	Bold    bool            // Emphasize active elements with bold text
+	NoColor bool            // Do not use any colors, mark active elements otherwise
+}
+
+// The theme used if no other theme is selected
+func DefaultTheme() Theme {
+	t := Theme{}
+
+	t.Colors.Primary.Foreground = lipgloss.AdaptiveColor{Light: "15", Dark: "15"}
+	t.Colors.Primary.Background = lipgloss.AdaptiveColor{Light: "56", Dark: "56"}
+
+	t.Colors.Active.Foreground = lipgloss.AdaptiveColor{Light: "12", Dark: "12"}
+	t.Colors.Active.Background = lipgloss.AdaptiveColor{Light: "200", Dark: "200"}
+
+	t.Colors.Error = lipgloss.AdaptiveColor{Light: "9", Dark: "9"}
+
+	return t
+}
+
+// Get the theme of a preset, an empty name is the default theme
+func Preset(name string) (Theme, error) {
+	t := DefaultTheme()
+
+	switch name {
+	case "", PresetDefault:
+		// Nothing to change
+
+	case PresetLight:
+		t.Colors.Primary.Foreground = lipgloss.Color("15")
+		t.Colors.Primary.Background = lipgloss.Color("55")
+		t.Colors.Active.Foreground = lipgloss.Color("15")
+		t.Colors.Active.Background = lipgloss.Color("125")
+		t.Colors.Error = lipgloss.Color("124")
+
+	case PresetDark:
+		t.Colors.Primary.Foreground = lipgloss.Color("255")
+		t.Colors.Primary.Background = lipgloss.Color("62")
+		t.Colors.Active.Foreground = lipgloss.Color("235")
+		t.Colors.Active.Background = lipgloss.Color("212")
+		t.Colors.Error = lipgloss.Color("203")
+
+	case PresetHighContrast:
+		t.Colors.Primary.Foreground = lipgloss.AdaptiveColor{Light: "15", Dark: "0"}
+		t.Colors.Primary.Background = lipgloss.AdaptiveColor{Light: "0", Dark: "15"}
+		t.Colors.Active.Foreground = lipgloss.AdaptiveColor{Light: "15", Dark: "0"}
+		t.Colors.Active.Background = lipgloss.AdaptiveColor{Light: "4", Dark: "11"}
+		t.Colors.Error = lipgloss.AdaptiveColor{Light: "1", Dark: "9"}
+		t.Border = lipgloss.ThickBorder()
+		t.Bold = true
+
+	case PresetNoColor:
+		t.Colors.Primary.Foreground = lipgloss.NoColor{}
+		t.Colors.Primary.Background = lipgloss.NoColor{}
+		t.Colors.Active.Foreground = lipgloss.NoColor{}
+		t.Colors.Active.Background = lipgloss.NoColor{}
+		t.Colors.Error = lipgloss.NoColor{}
+		t.NoColor = true
+
+	default:
+		return Theme{}, fmt.Errorf("unknown theme '%s', use %s", name, strings.Join(Presets(), ", "))
+	}
+
+	return t, nil
+}
+
+// Get the names of all presets
+func Presets() []string {
+	return []string{PresetDefault, PresetLight, PresetDark, PresetHighContrast, PresetNoColor}
+}
+
+// Either an ANSI color from 0 to 255 or a hex color
+var colorPattern = regexp.MustCompile(`^(\d{1,3}|#[0-9a-fA-F]{6}|#[0-9a-fA-F]{3})$`)
+
+// Replace colors of the theme, e.g. {"primary_background": "#5f00d7"}.
+// A color may be given as "light,dark" to use different colors depending
+// on the background of the terminal.
+func (t *Theme) ApplyColors(custom map[string]string) error {
+	colors := map[string]*lipgloss.TerminalColor{
+		"primary_foreground": &t.Colors.Primary.Foreground,
+		"primary_background": &t.Colors.Primary.Background,
+		"active_foreground":  &t.Colors.Active.Foreground,
+		"active_background":  &t.Colors.Active.Background,
+		"error":              &t.Colors.Error,
+	}
+
+	for name, value := range custom {
+		color, ok := colors[name]
+		if !ok {
+			return fmt.Errorf("unknown color '%s'", name)
+		}
+
+		c, err := parseColor(value)
+		if err != nil {
+			return fmt.Errorf("invalid value for color %s: %v", name, err)
+		}
+		*color = c
+	}
+
+	return nil
+}
+
+// Replace the border of the views, one of normal, rounded, thick, double or hidden
+func (t *Theme) ApplyBorder(name string) error {
+	switch name {
+	case "":
+		// Keep border of the theme
+	case "normal":
+		t.Border = lipgloss.NormalBorder()
+	case "rounded":
+		t.Border = lipgloss.RoundedBorder()
+	case "thick":
+		t.Border = lipgloss.ThickBorder()
+	case "double":
+		t.Border = lipgloss.DoubleBorder()
+	case "hidden":
+		t.Border = lipgloss.HiddenBorder()
+	default:
+		return fmt.Errorf("unknown border '%s', use normal, rounded, thick, double or hidden", name)
+	}
+
+	return nil
+}
+
+// Get the styles of a preset with custom colors and border applied
+func Load(preset string, colors map[string]string, border string) (*Styles, error) {
+	t, err := Preset(preset)
+	if err != nil {
+		return nil, err
+	}
+
+	// Custom colors would defeat the purpose of the no-color theme
+	if !t.NoColor {
+		if err := t.ApplyColors(colors); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := t.ApplyBorder(border); err != nil {
+		return nil, err
+	}
+
+	return NewWithTheme(t), nil
+}
+
+// Helper function to parse a color, either "color" or "light,dark"
+func parseColor(value string) (lipgloss.TerminalColor, error) {
+	light, dark, adaptive := strings.Cut(value, ",")
+	light, dark = strings.TrimSpace(light), strings.TrimSpace(dark)
+
+	for _, c := range []string{light, dark} {
+		if c == "" && !adaptive {
+			return nil, errors.New("no color given")
+		}
+		if c == "" {
+			continue // No color on this background
+		}
+
+		n, err := strconv.Atoi(c)
+		if !colorPattern.MatchString(c) || (err == nil && n > 255) {
+			return nil, fmt.Errorf("'%s' is neither an ANSI color (0-255) nor a hex color (#rrggbb)", c)
+		}
+	}
+
+	if !adaptive {
+		return lipgloss.Color(light), nil
+	}
+
+	return lipgloss.AdaptiveColor{Light: light, Dark: dark}, nil
+}
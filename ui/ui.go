@@ -3,23 +3,36 @@
 package ui
 
 import (
+	stdcontext "context"
+	"errors"
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/leschuster/deepl-cli/pkg/auth"
+	"github.com/leschuster/deepl-cli/pkg/cache"
+	"github.com/leschuster/deepl-cli/pkg/config"
 	deeplapi "github.com/leschuster/deepl-cli/pkg/deepl-api"
+	"github.com/leschuster/deepl-cli/pkg/history"
 	"github.com/leschuster/deepl-cli/ui/com"
 	"github.com/leschuster/deepl-cli/ui/components/header"
 	"github.com/leschuster/deepl-cli/ui/components/help"
 	"github.com/leschuster/deepl-cli/ui/context"
+	"github.com/leschuster/deepl-cli/ui/keys"
+	"github.com/leschuster/deepl-cli/ui/styles"
+	"github.com/leschuster/deepl-cli/ui/utils"
 	errorview "github.com/leschuster/deepl-cli/ui/views/error-view"
 	formalityview "github.com/leschuster/deepl-cli/ui/views/formality-view"
+	historyview "github.com/leschuster/deepl-cli/ui/views/history-view"
 	loginview "github.com/leschuster/deepl-cli/ui/views/login-view"
 	mainview "github.com/leschuster/deepl-cli/ui/views/main-view"
+	profileview "github.com/leschuster/deepl-cli/ui/views/profile-view"
 	srclangview "github.com/leschuster/deepl-cli/ui/views/src-lang-view"
+	taghandlingview "github.com/leschuster/deepl-cli/ui/views/tag-handling-view"
 	tarlangview "github.com/leschuster/deepl-cli/ui/views/tar-lang-view"
 )
 
@@ -30,6 +43,9 @@ const (
 	srcLangViewIdx
 	tarLangViewIdx
 	formalityViewIdx
+	tagHandlingViewIdx
+	historyViewIdx
+	profileViewIdx
 	loginViewIdx
 	errorViewIdx
 )
@@ -40,11 +56,18 @@ const (
 	helpHeight   = 6
 )
 
+// Maximum time to wait for a request, including retries
+const (
+	translateTimeout = 1 * time.Minute
+	usageTimeout     = 30 * time.Second
+)
+
 // The ui model is at the root of the application.
 // It is responsible for managing different views
 // and rendering the header and help.
 type Model struct {
 	auth     auth.Auth
+	config   config.Config
 	ctx      *context.ProgramContext
 	views    []tea.Model
 	currView ViewIdx
@@ -52,11 +75,59 @@ type Model struct {
 	quitting bool
 	header   header.Model
 	help     help.Model
+
+	// Number of the latest translation request.
+	// Responses to older requests are ignored.
+	translationSeq uint64
+
+	// Live translation that has not been added to the history yet,
+	// because the user is still typing
+	unrecorded *com.APITranslationReceivedMsg
+
+	// Last used regional variant of each target language, e.g. EN → EN-GB.
+	// Used to restore the variant when swapping languages back and forth.
+	targetVariants map[string]string
+}
+
+// Optional features of the user interface
+type Options struct {
+	Cache   *cache.Cache   // Translations are not cached if nil
+	History *history.Store // Translations are not recorded if nil
+	Config  config.Config  // Defaults set by the user
+	Keys    *keys.KeyMap   // Default keybindings if nil
+	Styles  *styles.Styles // Default theme if nil
+
+	// Names of all profiles to switch between, only the profile of auth if empty
+	Profiles []string
+
+	// Creates the translator for an API key.
+	// The DeepL client with the connection settings of Config if nil.
+	NewTranslator context.TranslatorFunc
 }
 
 // Get a new ui model
-func InitialModel(auth auth.Auth) Model {
+func InitialModel(auth auth.Auth, opts Options) Model {
 	ctx := context.New()
+	ctx.Cache = opts.Cache
+	ctx.History = opts.History
+	ctx.Formality = opts.Config.Formality
+	ctx.LiveTranslate = opts.Config.LiveTranslate
+	ctx.NewTranslator = opts.NewTranslator
+	if ctx.NewTranslator == nil {
+		ctx.NewTranslator = deeplTranslator(opts.Config.APIOptions()...)
+	}
+	if opts.Keys != nil {
+		ctx.Keys = *opts.Keys
+	}
+	if opts.Styles != nil {
+		ctx.Styles = opts.Styles
+	}
+	ctx.Profile = auth.Profile()
+	ctx.KeyOverride, _ = auth.Override()
+	ctx.Profiles = opts.Profiles
+	if len(ctx.Profiles) == 0 {
+		ctx.Profiles = []string{ctx.Profile}
+	}
 
 	// Setup available views
 	views := []tea.Model{
@@ -64,6 +135,9 @@ func InitialModel(auth auth.Auth) Model {
 		srclangview.InitialModel(ctx),
 		tarlangview.InitialModel(ctx),
 		formalityview.InitialModel(ctx),
+		taghandlingview.InitialModel(ctx),
+		historyview.InitialModel(ctx),
+		profileview.InitialModel(ctx),
 		loginview.InitialModel(ctx),
 		errorview.InitialModel(ctx),
 	}
@@ -72,7 +146,7 @@ func InitialModel(auth auth.Auth) Model {
 
 	if apiKey, err := auth.GetAPIKey(); err == nil {
 		// User is already signed in
-		ctx.Api = deeplapi.New(apiKey)
+		ctx.UseAPIKey(apiKey)
 	} else {
 		// User is not signed in
 		// Redirect to login view
@@ -81,11 +155,14 @@ func InitialModel(auth auth.Auth) Model {
 
 	return Model{
 		auth:     auth,
+		config:   opts.Config,
 		ctx:      ctx,
 		views:    views,
 		currView: currView,
 		header:   header.InitialModel(ctx),
 		help:     help.InitialModel(ctx, helpHeight),
+
+		targetVariants: map[string]string{},
 	}
 }
 
@@ -94,6 +171,8 @@ func (m Model) Init() tea.Cmd {
 	cmds := []tea.Cmd{
 		tea.SetWindowTitle("DeepL CLI (Unofficial)"), // Set Title
 		m.views[m.currView].Init(),                   // Initialize active view
+		m.fetchUsage(),                               // Show usage in header
+		m.loadDefaultLanguages(),                     // Preselect languages from the config
 	}
 
 	return tea.Batch(cmds...)
@@ -149,18 +228,36 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	// Did the user enter an API key?
 	case com.APIKeyEnteredMsg:
-		m.ctx.Api = deeplapi.New(msg.Key)
+		// Check the key before saving it
+		cmds = append(cmds, com.StartLoadingCmd())
+		cmds = append(cmds, m.validateAPIKey(msg.Key))
+
+		// Exit early so that no other component receives this message
+		return m, tea.Batch(cmds...)
+
+	// Did DeepL accept the entered API key?
+	case com.APIKeyValidatedMsg:
+		m.ctx.UseAPIKey(msg.Key)
+
+		// Let the login view start over the next time it is opened
+		model, cmd := m.views[loginViewIdx].Update(msg)
+		m.views[loginViewIdx] = model
+		cmds = append(cmds, cmd)
 
 		// Switch to main view
 		m.currView = mainViewIdx
+		cmds = append(cmds, com.StopLoadingCmd())
+		cmds = append(cmds, com.InsertModeExitedCmd())
 		cmds = append(cmds, m.views[m.currView].Init())
+		cmds = append(cmds, com.APIUsageReceivedCmd(msg.Usage))
+		cmds = append(cmds, m.loadDefaultLanguages())
 
 		// Define a command to save apikey locally
 		// Bubbletea will run it asynchronously
 		cmd = func() tea.Msg {
 			err := m.auth.SetApiKey(msg.Key)
 			if err != nil {
-				return com.ThrowErr(err)
+				return com.Err{Err: err}
 			}
 			return nil
 		}
@@ -169,14 +266,42 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Exit early so that no other component receives this message
 		return m, tea.Batch(cmds...)
 
+	// Did DeepL reject the entered API key?
+	// The login view shows why, so that the user can correct the key
+	case com.APIKeyRejectedMsg:
+		cmds = append(cmds, com.StopLoadingCmd())
+
+	// Did the user want to enter another API key?
+	case com.APIKeyChangeRequestedMsg:
+		m.currView = loginViewIdx
+		cmds = append(cmds, m.views[m.currView].Init())
+
+	// Did the user leave the login view without entering a key?
+	case com.LoginCancelledMsg:
+		m.currView = mainViewIdx
+
 	// Is it a key press?
 	case tea.KeyMsg:
-		// Pass it on to help
-		h, cmd := m.help.Update(msg)
-		m.help = h.(help.Model)
-		cmds = append(cmds, cmd)
-
+		// Help receives the key press below, together with all other messages
 		switch {
+		case key.Matches(msg, m.ctx.Keys.Unselect) && m.currView == mainViewIdx && !m.ctx.InsertMode && m.ctx.CancelTranslation != nil:
+			// Abort the translation in flight
+			m.ctx.CancelTranslation()
+			m.ctx.CancelTranslation = nil
+		case key.Matches(msg, m.ctx.Keys.History) && m.currView == mainViewIdx && !m.ctx.InsertMode && m.ctx.History != nil:
+			return m, com.HistoryOpenedCmd()
+		case key.Matches(msg, m.ctx.Keys.ChangeAPIKey) && m.currView == mainViewIdx && !m.ctx.InsertMode:
+			return m, com.APIKeyChangeRequestedCmd()
+		case key.Matches(msg, m.ctx.Keys.Profiles) && m.currView == mainViewIdx && !m.ctx.InsertMode && len(m.ctx.Profiles) > 1:
+			return m, com.ProfilesOpenedCmd()
+		case key.Matches(msg, m.ctx.Keys.Swap) && m.currView == mainViewIdx && !m.ctx.InsertMode:
+			return m, m.swapLanguages()
+		case key.Matches(msg, m.ctx.Keys.LiveTranslate) && m.currView == mainViewIdx && !m.ctx.InsertMode:
+			return m, com.LiveTranslateToggledCmd(!m.ctx.LiveTranslate)
+		case key.Matches(msg, m.ctx.Keys.Copy) && m.currView == mainViewIdx && !m.ctx.InsertMode:
+			if res := m.ctx.TranslationResult; res != nil && len(res.Translations) > 0 {
+				return m, utils.CopyToClipboard(res.Translations[0].Text)
+			}
 		case key.Matches(msg, m.ctx.Keys.Quit) && !m.ctx.InsertMode:
 			fallthrough
 		case key.Matches(msg, m.ctx.Keys.ForceQuit):
@@ -184,13 +309,46 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, tea.Quit
 		}
 
+	// Did the default languages from the config load?
+	case com.DefaultLanguagesLoadedMsg:
+		if msg.Source != nil {
+			m.ctx.SourceLanguage = msg.Source
+		}
+		if msg.Target != nil {
+			m.ctx.TargetLanguage = msg.Target
+		}
+
 	// Did the available languages request complete?
 	case com.APILanguagesReceivedMsg:
 		cmds = append(cmds, com.StopLoadingCmd())
 
 	// Did the translation request complete?
 	case com.APITranslationReceivedMsg:
+		if msg.Seq != m.translationSeq {
+			// A newer translation has been requested in the meantime,
+			// do not let this response overwrite it
+			return m, nil
+		}
+
+		m.ctx.CancelTranslation = nil
+		m.ctx.TranslationResult = msg.Resp
 		cmds = append(cmds, com.StopLoadingCmd())
+		cmds = append(cmds, m.fetchUsage()) // The translation consumed characters
+
+		if msg.Live && m.ctx.InsertMode {
+			// The user is still typing, only record the final translation
+			m.unrecorded = &msg
+		} else {
+			m.unrecorded = nil
+			m.record(msg)
+		}
+
+	// Did the user abort the translation request?
+	case com.APITranslationCancelledMsg:
+		if msg.Seq == m.translationSeq {
+			// No other translation is in flight
+			cmds = append(cmds, com.StopLoadingCmd())
+		}
 
 	// Did the user press the source language button?
 	case com.SrcLangBtnSelectedMsg:
@@ -222,65 +380,119 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.ctx.Formality = msg.Formality
 		m.currView = mainViewIdx
 
-	// Did we enter insert mode?
-	case com.InsertModeEnteredMsg:
-		m.ctx.InsertMode = true
+	// Did the user press the tag handling button?
+	case com.TagHandlingBtnSelectedMsg:
+		m.currView = tagHandlingViewIdx
+		return m, m.views[m.currView].Init()
 
-	// Did we exit insert mode?
-	case com.InsertModeExitedMsg:
-		m.ctx.InsertMode = false
+	// Did the user select a tag handling?
+	case com.TagHandlingSelectedMsg:
+		m.ctx.TagHandling = msg.TagHandling
+		m.currView = mainViewIdx
 
-	// Did the user press the translate button?
-	case com.TranslateBtnSelectedMsg:
-		if m.ctx.Api == nil {
-			return m, com.ThrowErr(fmt.Errorf("ctx.api is nil"))
+	// Did the user open the history?
+	case com.HistoryOpenedMsg:
+		m.currView = historyViewIdx
+		return m, m.views[m.currView].Init()
+
+	// Did the user leave the history?
+	case com.HistoryClosedMsg:
+		m.currView = mainViewIdx
+
+	// Did the user select an entry from the history?
+	case com.HistoryEntrySelectedMsg:
+		// Restore the state of the past translation
+		// Components in the main view update themselves
+		entry := msg.Entry
+		m.ctx.SourceLanguage = entry.Source
+		m.ctx.TargetLanguage = &entry.Target
+		if entry.Formality != "" {
+			// Entries of targets without formality must not reset the user's choice
+			m.ctx.Formality = entry.Formality
 		}
+		m.ctx.SourceText = entry.SourceText
+		m.ctx.TranslationResult = &deeplapi.TranslateResp{
+			Translations: []deeplapi.Translation{
+				{DetectedSourceLanguage: entry.DetectedLang, Text: entry.Result},
+			},
+		}
+		m.currView = mainViewIdx
 
-		cmds = append(cmds, com.StartLoadingCmd())
+	// Did the user swap source and target language?
+	case com.LanguagesSwappedMsg:
+		if tar := m.ctx.TargetLanguage; tar != nil {
+			m.targetVariants[deeplapi.BaseCode(tar.Language)] = tar.Language
+		}
 
-		// Define a command that will fetch the translation
-		// We return this command because Bubbletea handles
-		// commands asynchronously
-		cmd = func() tea.Msg {
-			srcLang := "" // if empty, DeepL will try to detect it
-			if m.ctx.SourceLanguage != nil {
-				srcLang = m.ctx.SourceLanguage.Language
-			}
+		src, tar := msg.Source, msg.Target
+		m.ctx.SourceLanguage = &src
+		m.ctx.TargetLanguage = &tar
+		m.ctx.SourceText = msg.SourceText
+		m.ctx.TranslationResult = &deeplapi.TranslateResp{
+			Translations: []deeplapi.Translation{{Text: msg.TargetText}},
+		}
 
-			if m.ctx.TargetLanguage == nil {
-				return com.Err{
-					Err: fmt.Errorf("no target language selected"),
-				}
-			}
-			tarLang := m.ctx.TargetLanguage.Language
+	// Did the user open the profile view?
+	case com.ProfilesOpenedMsg:
+		m.currView = profileViewIdx
+		return m, m.views[m.currView].Init()
 
-			formality := ""
-			if m.ctx.TargetLanguage.SupportsFormality {
-				formality = m.ctx.Formality
-			}
+	// Did the user leave the profile view?
+	case com.ProfilesClosedMsg:
+		m.currView = mainViewIdx
 
-			params := deeplapi.TranslateParams{
-				Text:       []string{m.ctx.SourceText},
-				SourceLang: srcLang,
-				TargetLang: tarLang,
-				Context:    "",
-				Formality:  formality,
-			}
+	// Did the user switch to another profile?
+	case com.ProfileSelectedMsg:
+		if err := m.auth.SetProfile(msg.Name); err != nil {
+			return m, com.ThrowErr(err)
+		}
+		m.ctx.Profile = msg.Name
 
-			resp, err := m.ctx.Api.Translate(params)
-			if err != nil {
-				return com.Err{
-					Err: fmt.Errorf("failed to fetch translation: %v", err),
-				}
-			}
+		// Abort the translation of the previous profile
+		if m.ctx.CancelTranslation != nil {
+			m.ctx.CancelTranslation()
+			m.ctx.CancelTranslation = nil
+		}
 
-			m.ctx.TranslationResult = resp
+		if apiKey, err := m.auth.GetAPIKey(); err == nil {
+			m.ctx.UseAPIKey(apiKey)
+			m.currView = mainViewIdx
+			cmds = append(cmds, m.fetchUsage())
+		} else {
+			// The profile has no API key yet
+			m.ctx.Api, m.ctx.Endpoint = nil, ""
+			m.currView = loginViewIdx
+			cmds = append(cmds, m.views[m.currView].Init())
+		}
 
-			return com.APITranslationReceivedMsg{}
+	// Did we enter insert mode?
+	case com.InsertModeEnteredMsg:
+		m.ctx.InsertMode = true
+
+	// Did we exit insert mode?
+	case com.InsertModeExitedMsg:
+		m.ctx.InsertMode = false
+
+		// Record the live translation the user ended up with
+		if m.unrecorded != nil {
+			m.record(*m.unrecorded)
+			m.unrecorded = nil
 		}
-		cmds = append(cmds, cmd)
 
-		return m, tea.Batch(cmds...)
+	// Did the user press the translate button?
+	case com.TranslateBtnSelectedMsg:
+		return m.translate(false)
+
+	// Did the user stop typing while live translation is enabled?
+	case com.LiveTranslateTriggeredMsg:
+		if !m.ctx.LiveTranslate || strings.TrimSpace(m.ctx.SourceText) == "" || m.ctx.TargetLanguage == nil {
+			return m, nil
+		}
+		return m.translate(true)
+
+	// Did the user toggle live translation?
+	case com.LiveTranslateToggledMsg:
+		m.ctx.LiveTranslate = msg.Enabled
 	}
 
 	// Pass msg to header
@@ -320,10 +532,265 @@ func (m Model) View() string {
 	)
 }
 
+// Get a command that resolves the default languages from the config.
+// The language codes are mapped to the languages DeepL offers, e.g. EN becomes EN-GB as target.
+func (m Model) loadDefaultLanguages() tea.Cmd {
+	api := m.ctx.Api
+	srcCode, tarCode := m.config.SourceLang, m.config.TargetLang
+	if api == nil || (srcCode == "" && tarCode == "") {
+		return nil
+	}
+
+	al := &m.ctx.AvailableLanguages
+
+	return func() tea.Msg {
+		if msg, ok := al.LoadInitial(api)().(com.Err); ok {
+			return msg
+		}
+
+		msg := com.DefaultLanguagesLoadedMsg{}
+
+		if srcCode != "" {
+			lang, ok := al.SourceFor(srcCode)
+			if !ok {
+				return com.Err{Err: fmt.Errorf("source language %s from the config is not supported", srcCode)}
+			}
+			msg.Source = &lang
+		}
+
+		if tarCode != "" {
+			lang, ok := al.TargetFor(tarCode, "")
+			if !ok {
+				return com.Err{Err: fmt.Errorf("target language %s from the config is not supported", tarCode)}
+			}
+			msg.Target = &lang
+		}
+
+		return msg
+	}
+}
+
+// Get a command that checks whether DeepL accepts an API key.
+// The usage is requested because it is cheap and does not consume any characters.
+func (m Model) validateAPIKey(apiKey string) tea.Cmd {
+	api, _ := m.ctx.NewTranslator(apiKey)
+
+	return func() tea.Msg {
+		reqCtx, cancel := stdcontext.WithTimeout(stdcontext.Background(), usageTimeout)
+		defer cancel()
+
+		usage, err := api.GetUsageCtx(reqCtx)
+		if err != nil {
+			return com.APIKeyRejectedMsg{Err: err}
+		}
+
+		return com.APIKeyValidatedMsg{Key: apiKey, Usage: *usage}
+	}
+}
+
+// Get a command that fetches the usage of the account.
+// Failures are ignored because the usage is only informational.
+func (m Model) fetchUsage() tea.Cmd {
+	api := m.ctx.Api
+	if api == nil {
+		return nil
+	}
+
+	return func() tea.Msg {
+		reqCtx, cancel := stdcontext.WithTimeout(stdcontext.Background(), usageTimeout)
+		defer cancel()
+
+		usage, err := api.GetUsageCtx(reqCtx)
+		if err != nil {
+			return nil
+		}
+
+		return com.APIUsageReceivedMsg{Usage: *usage}
+	}
+}
+
+// Start a translation of the source text and return the updated model.
+// A translation that is still in flight is aborted.
+// Live translations are triggered by typing instead of the translate button.
+func (m Model) translate(live bool) (tea.Model, tea.Cmd) {
+	if m.ctx.Api == nil {
+		return m, com.ThrowErr(fmt.Errorf("ctx.api is nil"))
+	}
+
+	if m.ctx.TargetLanguage == nil {
+		return m, com.ThrowErr(fmt.Errorf("no target language selected"))
+	}
+
+	// Copy the languages, they may change until the translation is received
+	var source *deeplapi.Language
+	srcLang := "" // if empty, DeepL will try to detect it
+	if m.ctx.SourceLanguage != nil {
+		lang := *m.ctx.SourceLanguage
+		source = &lang
+		srcLang = lang.Language
+	}
+	target := *m.ctx.TargetLanguage
+
+	formality := ""
+	if m.ctx.TargetLanguage.SupportsFormality {
+		formality = m.ctx.Formality
+	}
+
+	params := deeplapi.TranslateParams{
+		Text:        []string{m.ctx.SourceText},
+		SourceLang:  srcLang,
+		TargetLang:  target.Language,
+		Context:     m.ctx.ContextText,
+		Formality:   formality,
+		TagHandling: m.ctx.TagHandling,
+	}
+
+	// Abort the previous translation if it is still in flight
+	if m.ctx.CancelTranslation != nil {
+		m.ctx.CancelTranslation()
+	}
+	reqCtx, cancel := stdcontext.WithTimeout(stdcontext.Background(), translateTimeout)
+	m.ctx.CancelTranslation = cancel
+
+	m.translationSeq++
+	seq := m.translationSeq
+
+	api, endpoint := m.ctx.Api, m.ctx.Endpoint
+	c := m.ctx.Cache
+
+	// Define a command that will fetch the translation
+	// We return this command because Bubbletea handles
+	// commands asynchronously
+	cmd := func() tea.Msg {
+		defer cancel()
+
+		var resp *deeplapi.TranslateResp
+		var err error
+		switch {
+		case c == nil:
+			resp, err = api.TranslateCtx(reqCtx, params)
+		case live:
+			// The user is still typing, only the final text is cached, see record
+			var ok bool
+			if resp, ok = c.Get(endpoint, params); !ok {
+				resp, err = api.TranslateCtx(reqCtx, params)
+			}
+		default:
+			resp, err = c.Translate(reqCtx, endpoint, params, api.TranslateCtx)
+		}
+		if errors.Is(err, stdcontext.Canceled) {
+			return com.APITranslationCancelledMsg{Seq: seq}
+		}
+		if err != nil {
+			return com.Err{
+				Err: fmt.Errorf("failed to fetch translation: %w", err),
+			}
+		}
+
+		return com.APITranslationReceivedMsg{
+			Seq:      seq,
+			Live:     live,
+			Endpoint: endpoint,
+			Params:   params,
+			Resp:     resp,
+			Source:   source,
+			Target:   target,
+		}
+	}
+
+	return m, tea.Batch(com.StartLoadingCmd(), cmd)
+}
+
+// Get a command that swaps source and target language.
+// The translation becomes the new source text and vice versa.
+// If the source language is detected automatically, the detected language is used.
+// Nothing happens if there is no source language to swap to yet.
+func (m Model) swapLanguages() tea.Cmd {
+	api := m.ctx.Api
+	if api == nil || m.ctx.TargetLanguage == nil {
+		return nil
+	}
+
+	var sourceText, targetText, detected string
+	sourceText = m.ctx.SourceText
+	if res := m.ctx.TranslationResult; res != nil && len(res.Translations) > 0 {
+		targetText = res.Translations[0].Text
+		detected = res.Translations[0].DetectedSourceLanguage
+	}
+
+	srcCode := detected
+	if m.ctx.SourceLanguage != nil {
+		srcCode = m.ctx.SourceLanguage.Language
+	}
+	if srcCode == "" {
+		return nil
+	}
+
+	tarCode := m.ctx.TargetLanguage.Language
+	preferred := m.targetVariants[deeplapi.BaseCode(srcCode)]
+	al := &m.ctx.AvailableLanguages
+
+	return func() tea.Msg {
+		// Languages are needed to map regional variants
+		if msg, ok := al.LoadInitial(api)().(com.Err); ok {
+			return msg
+		}
+
+		newSrc, ok := al.SourceFor(tarCode)
+		if !ok {
+			return com.Err{Err: fmt.Errorf("%s is not supported as a source language", tarCode)}
+		}
+
+		newTar, ok := al.TargetFor(srcCode, preferred)
+		if !ok {
+			return com.Err{Err: fmt.Errorf("%s is not supported as a target language", srcCode)}
+		}
+
+		return com.LanguagesSwappedCmd(newSrc, newTar, targetText, sourceText)()
+	}
+}
+
+// Keep the translation the user ended up with in the history and, if it is
+// a live translation, in the cache. Other translations are cached right away.
+func (m Model) record(msg com.APITranslationReceivedMsg) {
+	m.recordHistory(msg)
+
+	if msg.Live && m.ctx.Cache != nil {
+		_ = m.ctx.Cache.Put(msg.Endpoint, msg.Params, msg.Resp)
+	}
+}
+
+// Add a translation to the history, with the languages it was requested for.
+// Failures are ignored because the history is only informational.
+func (m Model) recordHistory(msg com.APITranslationReceivedMsg) {
+	store := m.ctx.History
+	if store == nil || len(msg.Resp.Translations) == 0 {
+		return
+	}
+
+	_ = store.Add(history.Entry{
+		SourceText:   msg.Params.Text[0],
+		Source:       msg.Source,
+		Target:       msg.Target,
+		Formality:    msg.Params.Formality,
+		DetectedLang: msg.Resp.Translations[0].DetectedSourceLanguage,
+		Result:       msg.Resp.Translations[0].Text,
+	})
+}
+
+// Get a TranslatorFunc that creates DeepL clients.
+// Texts exceeding the limits of a single request are split.
+func deeplTranslator(opts ...deeplapi.Option) context.TranslatorFunc {
+	return func(apiKey string) (deeplapi.Translator, string) {
+		api := deeplapi.New(apiKey, opts...)
+		return deeplapi.BatchTranslator{DeeplAPI: api}, api.BaseURL()
+	}
+}
+
 // Start the application and show the user interface
-func Run(auth auth.Auth) {
+func Run(auth auth.Auth, opts Options) {
 	// Create a new program occupying the whole screen
-	p := tea.NewProgram(InitialModel(auth), tea.WithAltScreen())
+	p := tea.NewProgram(InitialModel(auth, opts), tea.WithAltScreen())
 
 	if _, err := p.Run(); err != nil {
 		fmt.Fprintf(os.Stderr, "There has been an error: %v\n", err)
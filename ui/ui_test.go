@@ -0,0 +1,261 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/leschuster/deepl-cli/pkg/auth"
+	"github.com/leschuster/deepl-cli/pkg/cache"
+	deeplapi "github.com/leschuster/deepl-cli/pkg/deepl-api"
+	"github.com/leschuster/deepl-cli/pkg/deepl-api/fake"
+	"github.com/leschuster/deepl-cli/pkg/history"
+	"github.com/leschuster/deepl-cli/ui/com"
+)
+
+// Helper function to get a signed in model whose API key comes from a file
+func newTestModel(t *testing.T, opts Options) Model {
+	t.Helper()
+
+	keyFile := filepath.Join(t.TempDir(), "api-key")
+	if err := os.WriteFile(keyFile, []byte("test-key:fx"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	a := auth.New("com.leschuster.deepl-cli.test", "test")
+	a.SetKeyFile(keyFile)
+
+	m := InitialModel(a, opts)
+	model, _ := m.Update(tea.WindowSizeMsg{Width: 200, Height: 50})
+	return model.(Model)
+}
+
+// Helper function to get options that translate with a fake DeepL server
+func fakeOptions(t *testing.T) Options {
+	t.Helper()
+
+	srv := fake.NewServer(fake.WithAuthKey("test-key:fx"))
+	t.Cleanup(srv.Close)
+
+	return Options{
+		NewTranslator: func(apiKey string) (deeplapi.Translator, string) {
+			return deeplapi.New(apiKey, deeplapi.WithBaseURL(srv.URL)), srv.URL
+		},
+	}
+}
+
+// Helper function to run a command and all commands it batches, returning their messages
+func run(cmd tea.Cmd) []tea.Msg {
+	if cmd == nil {
+		return nil
+	}
+
+	switch msg := cmd().(type) {
+	case tea.BatchMsg:
+		var msgs []tea.Msg
+		for _, c := range msg {
+			msgs = append(msgs, run(c)...)
+		}
+		return msgs
+	case nil:
+		return nil
+	default:
+		return []tea.Msg{msg}
+	}
+}
+
+// Helper function to find the first message of type T
+func find[T tea.Msg](msgs []tea.Msg) (T, bool) {
+	for _, msg := range msgs {
+		if res, ok := msg.(T); ok {
+			return res, true
+		}
+	}
+
+	var zero T
+	return zero, false
+}
+
+// Helper function to send a key press to the model
+func press(m Model, msg tea.KeyMsg) Model {
+	model, _ := m.Update(msg)
+	return model.(Model)
+}
+
+func TestFullHelpToggle(t *testing.T) {
+	m := newTestModel(t, Options{})
+	toggle := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("?")}
+	swapHelp := m.ctx.Keys.Swap.Help().Desc
+
+	if strings.Contains(m.help.View(), swapHelp) {
+		t.Fatal("expected the short help initially")
+	}
+
+	m = press(m, toggle)
+	if !strings.Contains(m.help.View(), swapHelp) {
+		t.Fatal("expected the full help after pressing ?")
+	}
+
+	m = press(m, toggle)
+	if strings.Contains(m.help.View(), swapHelp) {
+		t.Fatal("expected the short help after pressing ? again")
+	}
+}
+
+func TestTranslateWithInjectedTranslator(t *testing.T) {
+	m := newTestModel(t, fakeOptions(t))
+	m.ctx.TargetLanguage = &deeplapi.Language{Language: "DE"}
+	m.ctx.SourceText = "Hello"
+
+	model, cmd := m.translate(false)
+	m = model.(Model)
+
+	msg, ok := find[com.APITranslationReceivedMsg](run(cmd))
+	if !ok {
+		t.Fatal("expected a translation to be received")
+	}
+
+	model, _ = m.Update(msg)
+	m = model.(Model)
+
+	if got := m.ctx.TranslationResult.Translations[0].Text; got != "[DE] Hello" {
+		t.Errorf("got translation %q, want %q", got, "[DE] Hello")
+	}
+}
+
+func TestEndpointOfInjectedTranslator(t *testing.T) {
+	opts := fakeOptions(t)
+	m := newTestModel(t, opts)
+
+	_, want := opts.NewTranslator("test-key:fx")
+	if m.ctx.Endpoint != want {
+		t.Errorf("got endpoint %q, want %q", m.ctx.Endpoint, want)
+	}
+}
+
+func TestOnlyFinalLiveTranslationIsCached(t *testing.T) {
+	opts := fakeOptions(t)
+	opts.Cache = cache.New(t.TempDir(), cache.DefaultTTL, cache.DefaultMaxSize)
+	m := newTestModel(t, opts)
+	m.ctx.TargetLanguage = &deeplapi.Language{Language: "DE"}
+	m.ctx.InsertMode = true
+
+	for _, text := range []string{"Hel", "Hello"} {
+		m.ctx.SourceText = text
+
+		model, cmd := m.translate(true)
+		m = model.(Model)
+
+		msg, ok := find[com.APITranslationReceivedMsg](run(cmd))
+		if !ok {
+			t.Fatal("expected a translation to be received")
+		}
+		model, _ = m.Update(msg)
+		m = model.(Model)
+	}
+
+	if stats, _ := opts.Cache.Stats(); stats.Entries != 0 {
+		t.Fatalf("got %d cached entries while typing, want 0", stats.Entries)
+	}
+
+	model, _ := m.Update(com.InsertModeExitedMsg{})
+	m = model.(Model)
+
+	params := deeplapi.TranslateParams{Text: []string{"Hello"}, TargetLang: "DE"}
+	if _, ok := opts.Cache.Get(m.ctx.Endpoint, params); !ok {
+		t.Error("expected the final live translation to be cached")
+	}
+	if stats, _ := opts.Cache.Stats(); stats.Entries != 1 {
+		t.Errorf("got %d cached entries, want 1", stats.Entries)
+	}
+}
+
+func TestHistoryKeepsRequestedLanguages(t *testing.T) {
+	opts := fakeOptions(t)
+	opts.History = history.New(filepath.Join(t.TempDir(), "history.json"), history.DefaultMaxEntries)
+	m := newTestModel(t, opts)
+	m.ctx.SourceLanguage = &deeplapi.Language{Language: "EN"}
+	m.ctx.TargetLanguage = &deeplapi.Language{Language: "DE"}
+	m.ctx.SourceText = "Hello"
+
+	model, cmd := m.translate(false)
+	m = model.(Model)
+
+	// The user selects other languages while the translation is in flight
+	m.ctx.SourceLanguage = &deeplapi.Language{Language: "FR"}
+	m.ctx.TargetLanguage = &deeplapi.Language{Language: "ES"}
+
+	msg, ok := find[com.APITranslationReceivedMsg](run(cmd))
+	if !ok {
+		t.Fatal("expected a translation to be received")
+	}
+	m.Update(msg)
+
+	entries, err := opts.History.List()
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("got %d history entries (%v), want 1", len(entries), err)
+	}
+	if got := entries[0].Source.Language; got != "EN" {
+		t.Errorf("got source language %s, want EN", got)
+	}
+	if got := entries[0].Target.Language; got != "DE" {
+		t.Errorf("got target language %s, want DE", got)
+	}
+}
+
+func TestLoginViewStartsOverAfterValidation(t *testing.T) {
+	m := newTestModel(t, fakeOptions(t))
+
+	model, _ := m.Update(com.APIKeyChangeRequestedMsg{})
+	m = model.(Model)
+
+	m = press(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("test-key:fx")})
+	model, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = model.(Model)
+
+	entered, ok := find[com.APIKeyEnteredMsg](run(cmd))
+	if !ok {
+		t.Fatal("expected the key to be entered")
+	}
+
+	// The key is not saved, so that the test does not touch the keyring
+	model, cmd = m.Update(entered)
+	m = model.(Model)
+	validated, ok := find[com.APIKeyValidatedMsg](run(cmd))
+	if !ok {
+		t.Fatal("expected the fake to accept the key")
+	}
+	model, _ = m.Update(validated)
+	m = model.(Model)
+
+	// Change the key a second time
+	model, _ = m.Update(com.APIKeyChangeRequestedMsg{})
+	m = model.(Model)
+	m = press(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("other-key:fx")})
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if got, _ := find[com.APIKeyEnteredMsg](run(cmd)); got.Key != "other-key:fx" {
+		t.Errorf("got entered key %q, want other-key:fx", got.Key)
+	}
+}
+
+func TestHistoryEntryKeepsFormalityIfItHasNone(t *testing.T) {
+	m := newTestModel(t, Options{})
+	m.ctx.Formality = deeplapi.FormalityMore
+
+	entry := history.Entry{Target: deeplapi.Language{Language: "EN-GB"}, SourceText: "Hallo", Result: "Hello"}
+	model, _ := m.Update(com.HistoryEntrySelectedMsg{Entry: entry})
+	m = model.(Model)
+	if m.ctx.Formality != deeplapi.FormalityMore {
+		t.Errorf("got formality %q, want the user's choice to be kept", m.ctx.Formality)
+	}
+
+	entry = history.Entry{Target: deeplapi.Language{Language: "DE", SupportsFormality: true}, Formality: deeplapi.FormalityLess}
+	model, _ = m.Update(com.HistoryEntrySelectedMsg{Entry: entry})
+	m = model.(Model)
+	if m.ctx.Formality != deeplapi.FormalityLess {
+		t.Errorf("got formality %q, want the formality of the entry", m.ctx.Formality)
+	}
+}
@@ -1,14 +1,19 @@
 package utils
 
 import (
+	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	deeplapi "github.com/leschuster/deepl-cli/pkg/deepl-api"
 	"github.com/leschuster/deepl-cli/ui/com"
 )
 
+// Maximum time to wait for the available languages, including retries
+const loadTimeout = 1 * time.Minute
+
 // AvailableLanguages holds lists of all source/target languages
 // that DeepL has to offer. It also provides a method to fetch them.
 type AvailableLanguages struct {
@@ -23,9 +28,9 @@ func NewAvailableLanguages() AvailableLanguages {
 	}
 }
 
-// LoadInitial is a tea.Cmd that fetches available languages from DeepL
+// LoadInitial is a tea.Cmd that fetches available languages from the translator
 // if they are not fetched yet.
-func (al *AvailableLanguages) LoadInitial(api deeplapi.DeeplAPI) func() tea.Msg {
+func (al *AvailableLanguages) LoadInitial(api deeplapi.Translator) func() tea.Msg {
 	return func() tea.Msg {
 		al.mu.Lock()
 		defer al.mu.Unlock()
@@ -38,9 +43,12 @@ func (al *AvailableLanguages) LoadInitial(api deeplapi.DeeplAPI) func() tea.Msg
 			return com.APILanguagesReceivedMsg{}
 		}
 
-		resp, err := api.GetLanguages()
+		ctx, cancel := context.WithTimeout(context.Background(), loadTimeout)
+		defer cancel()
+
+		resp, err := api.GetLanguagesCtx(ctx)
 		if err != nil {
-			return com.ThrowErr(err)
+			return com.Err{Err: err}
 		}
 
 		al.srcLangs = resp.Source
@@ -71,3 +79,47 @@ func (al *AvailableLanguages) GetTargetLanguages() ([]deeplapi.Language, error)
 
 	return al.tarLangs, nil
 }
+
+// Find the source language that corresponds to a target language.
+// Source languages do not have regional variants, e.g. EN-GB becomes EN.
+func (al *AvailableLanguages) SourceFor(code string) (deeplapi.Language, bool) {
+	langs, err := al.GetSourceLanguages()
+	if err != nil {
+		return deeplapi.Language{}, false
+	}
+
+	if lang, ok := deeplapi.FindLanguage(langs, code); ok {
+		return lang, true
+	}
+
+	return deeplapi.FindLanguage(langs, deeplapi.BaseCode(code))
+}
+
+// Find the target language that corresponds to a source language.
+// If the target language has regional variants, e.g. EN becomes EN-GB or EN-US,
+// preferred is used if it is one of them. Otherwise, the first variant is used.
+func (al *AvailableLanguages) TargetFor(code, preferred string) (deeplapi.Language, bool) {
+	langs, err := al.GetTargetLanguages()
+	if err != nil {
+		return deeplapi.Language{}, false
+	}
+
+	if lang, ok := deeplapi.FindLanguage(langs, code); ok {
+		return lang, true
+	}
+
+	base := deeplapi.BaseCode(code)
+	if deeplapi.BaseCode(preferred) == base {
+		if lang, ok := deeplapi.FindLanguage(langs, preferred); ok {
+			return lang, true
+		}
+	}
+
+	for _, lang := range langs {
+		if deeplapi.BaseCode(lang.Language) == base {
+			return lang, true
+		}
+	}
+
+	return deeplapi.Language{}, false
+}
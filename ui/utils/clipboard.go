@@ -0,0 +1,69 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/atotto/clipboard"
+	"github.com/aymanbagabas/go-osc52/v2"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/leschuster/deepl-cli/ui/com"
+)
+
+// CopyToClipboard is a tea.Cmd that copies text to the system clipboard.
+// If there is no system clipboard, e.g. in an SSH session or on a headless Linux,
+// the text is sent to the terminal as OSC 52 escape sequence instead.
+func CopyToClipboard(text string) func() tea.Msg {
+	return func() tea.Msg {
+		if !isRemote() && !clipboard.Unsupported {
+			if err := clipboard.WriteAll(text); err == nil {
+				return nil
+			}
+		}
+
+		if err := writeOSC52(text); err != nil {
+			return com.Err{Err: fmt.Errorf("could not copy to clipboard: %v", err)}
+		}
+
+		return nil
+	}
+}
+
+// PasteFromClipboard is a tea.Cmd that reads text from the system clipboard
+func PasteFromClipboard() func() tea.Msg {
+	return func() tea.Msg {
+		if clipboard.Unsupported {
+			return com.Err{Err: errors.New("could not paste from clipboard: no clipboard available, use the paste function of your terminal instead")}
+		}
+
+		text, err := clipboard.ReadAll()
+		if err != nil {
+			return com.Err{Err: fmt.Errorf("could not paste from clipboard: %v", err)}
+		}
+
+		return com.ClipboardPastedMsg{Text: text}
+	}
+}
+
+// Helper function to check whether we are running in an SSH session.
+// The system clipboard would belong to the remote machine then.
+func isRemote() bool {
+	return os.Getenv("SSH_TTY") != "" || os.Getenv("SSH_CONNECTION") != ""
+}
+
+// Helper function to send text to the terminal's clipboard.
+// Stderr is used so that the sequence does not interfere with the rendered view.
+func writeOSC52(text string) error {
+	seq := osc52.New(text)
+
+	switch {
+	case os.Getenv("TMUX") != "":
+		seq = seq.Tmux()
+	case os.Getenv("STY") != "":
+		seq = seq.Screen()
+	}
+
+	_, err := seq.WriteTo(os.Stderr)
+	return err
+}
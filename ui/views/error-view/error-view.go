@@ -5,6 +5,7 @@ package errorview
 import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	deeplapi "github.com/leschuster/deepl-cli/pkg/deepl-api"
 	"github.com/leschuster/deepl-cli/ui/com"
 	"github.com/leschuster/deepl-cli/ui/context"
 )
@@ -12,6 +13,7 @@ import (
 type Model struct {
 	ctx                         *context.ProgramContext
 	err                         string
+	hint                        string
 	contentWidth, contentHeight int
 }
 
@@ -33,6 +35,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.contentWidth, m.contentHeight = m.ctx.ContentWidth, m.ctx.ContentHeight
 	case com.Err:
 		m.err = msg.Error()
+		m.hint = hint(msg.Err)
 	}
 
 	return m, tea.Batch(cmds...)
@@ -55,6 +58,14 @@ func (m Model) View() string {
 			Render(m.err),
 	)
 
+	if m.hint != "" {
+		content = lipgloss.JoinVertical(
+			lipgloss.Left,
+			content,
+			"\nHint: "+m.hint,
+		)
+	}
+
 	style := m.ctx.Styles.ErrorView.Style.Width(min(m.contentWidth-4, 50))
 
 	return lipgloss.Place(
@@ -64,3 +75,21 @@ func (m Model) View() string {
 		lipgloss.WithWhitespaceChars(" "),
 	)
 }
+
+// Helper function to suggest how the user may resolve an error
+func hint(err error) string {
+	switch {
+	case deeplapi.IsAuthFailed(err):
+		return "DeepL rejected your API key. Please make sure it is valid."
+	case deeplapi.IsQuotaExceeded(err):
+		return "The character limit of your DeepL plan has been reached."
+	case deeplapi.IsRateLimited(err):
+		return "DeepL received too many requests. Please wait a moment and try again."
+	case deeplapi.IsTooLarge(err):
+		return "The text is too large to be translated at once."
+	case deeplapi.IsServerError(err):
+		return "DeepL is temporarily unavailable. Please try again later."
+	default:
+		return ""
+	}
+}
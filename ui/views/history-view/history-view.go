@@ -0,0 +1,170 @@
+// Package historyview provides the view where the user is able to browse
+// past translations, re-open them or delete them.
+
+package historyview
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/leschuster/deepl-cli/pkg/history"
+	"github.com/leschuster/deepl-cli/ui/com"
+	"github.com/leschuster/deepl-cli/ui/components/list"
+	"github.com/leschuster/deepl-cli/ui/context"
+)
+
+// Maximum number of characters of the source text shown per entry
+const previewLength = 60
+
+type Model struct {
+	ctx                         *context.ProgramContext
+	list                        list.Model[history.Entry]
+	contentWidth, contentHeight int
+}
+
+func InitialModel(ctx *context.ProgramContext) Model {
+	return Model{
+		ctx:  ctx,
+		list: list.InitialModel[history.Entry](ctx, "History:"),
+	}
+}
+
+func (m Model) Init() tea.Cmd {
+	if m.ctx.History == nil {
+		return com.ThrowErr(fmt.Errorf("history is not available"))
+	}
+
+	return m.load()
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	var cmds []tea.Cmd
+
+	switch msg := msg.(type) {
+	case com.ContentSizeMsg:
+		m.contentWidth, m.contentHeight = m.ctx.ContentWidth, m.ctx.ContentHeight
+		w, h := m.calcListSize()
+		m.list.Resize(w, h)
+
+	case com.HistoryLoadedMsg:
+		items := make([]list.Item[history.Entry], len(msg.Entries))
+		for i, e := range msg.Entries {
+			items[i] = list.NewItem(preview(e.SourceText), languages(e), e)
+		}
+		m.list.SetItems(items)
+
+	case tea.KeyMsg:
+		switch {
+		case m.list.IsFiltering():
+			// Keystrokes belong to the filter
+
+		case key.Matches(msg, m.ctx.Keys.Select):
+			// User selected an entry
+			item, ok := m.list.GetSelected()
+			if !ok || item == nil {
+				return m, nil
+			}
+
+			return m, com.HistoryEntrySelectedCmd((*item).Data())
+
+		case key.Matches(msg, m.ctx.Keys.Delete):
+			// User wants to delete an entry
+			item, ok := m.list.GetSelected()
+			if !ok || item == nil {
+				return m, nil
+			}
+
+			return m, m.delete((*item).Data().ID)
+
+		case key.Matches(msg, m.ctx.Keys.Unselect) && !m.list.IsFiltered():
+			// User wants to go back
+			return m, com.HistoryClosedCmd()
+		}
+	}
+
+	l, cmd := m.list.Update(msg)
+	m.list = l.(list.Model[history.Entry])
+	cmds = append(cmds, cmd)
+
+	return m, tea.Batch(cmds...)
+}
+
+func (m Model) View() string {
+	style := m.ctx.Styles.LangView.Style
+
+	content := style.Render(m.list.View())
+
+	// Place content in the center of the screen
+	return lipgloss.Place(
+		m.contentWidth, m.contentHeight,
+		lipgloss.Center, lipgloss.Center,
+		content,
+		lipgloss.WithWhitespaceChars(" "),
+	)
+}
+
+// Get a command that reads all entries
+func (m Model) load() tea.Cmd {
+	store := m.ctx.History
+
+	return func() tea.Msg {
+		entries, err := store.List()
+		if err != nil {
+			return com.Err{Err: err}
+		}
+
+		return com.HistoryLoadedMsg{Entries: entries}
+	}
+}
+
+// Get a command that deletes an entry and reads the remaining ones
+func (m Model) delete(id string) tea.Cmd {
+	store := m.ctx.History
+
+	return func() tea.Msg {
+		if err := store.Delete(id); err != nil {
+			return com.Err{Err: err}
+		}
+
+		entries, err := store.List()
+		if err != nil {
+			return com.Err{Err: err}
+		}
+
+		return com.HistoryLoadedMsg{Entries: entries}
+	}
+}
+
+func (m *Model) calcListSize() (width, height int) {
+	width = min(previewLength+20, m.contentWidth)
+	height = max(20, int(0.75*float32(m.contentHeight))-4)
+	return
+}
+
+// Helper function to get a single line summary of a text
+func preview(text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+
+	if runes := []rune(text); len(runes) > previewLength {
+		text = string(runes[:previewLength-1]) + "…"
+	}
+
+	return text
+}
+
+// Helper function to describe the languages of an entry, e.g. "EN→DE"
+func languages(e history.Entry) string {
+	src := e.DetectedLang
+	if e.Source != nil {
+		src = e.Source.Language
+	}
+	if src == "" {
+		src = "auto"
+	}
+
+	return fmt.Sprintf("%s→%s", src, e.Target.Language)
+}
@@ -3,10 +3,14 @@
 package loginview
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	deeplapi "github.com/leschuster/deepl-cli/pkg/deepl-api"
 	"github.com/leschuster/deepl-cli/ui/com"
 	"github.com/leschuster/deepl-cli/ui/context"
 )
@@ -15,6 +19,8 @@ type Model struct {
 	ctx                         *context.ProgramContext
 	input                       textinput.Model
 	contentWidth, contentHeight int
+	validating                  bool   // Whether the entered key is being checked
+	err                         string // Why the entered key has been rejected
 }
 
 func InitialModel(ctx *context.ProgramContext) Model {
@@ -40,13 +46,43 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case com.ContentSizeMsg:
 		m.contentWidth, m.contentHeight = m.ctx.ContentWidth, m.ctx.ContentHeight
 		m.input.Width = min(m.contentWidth-4, 50)
+	// Start over when the user wants to replace the key
+	case com.APIKeyChangeRequestedMsg:
+		m.input.SetValue("")
+		m.validating = false
+		m.err = ""
+
+	// Forget the key once it has been accepted
+	case com.APIKeyValidatedMsg:
+		m.input.SetValue("")
+		m.validating = false
+		m.err = ""
+
+	// Let the user correct the key
+	case com.APIKeyRejectedMsg:
+		m.validating = false
+		m.err = describe(msg.Err)
+
 	case tea.KeyMsg:
 		switch {
+		case m.validating:
+			// Wait for the result
+			return m, nil
+
 		case key.Matches(msg, m.ctx.Keys.Select):
-			apiKey := m.input.Value()
+			apiKey := strings.TrimSpace(m.input.Value())
+			if apiKey == "" {
+				return m, nil
+			}
+
+			// Insert mode is left once the key has been accepted
+			m.validating = true
+			m.err = ""
+			return m, com.APIKeyEnteredCmd(apiKey)
 
-			cmds = append(cmds, com.InsertModeExitedCmd())
-			cmds = append(cmds, com.APIKeyEnteredCmd(apiKey))
+		// Going back is only possible if there is a key to go back to
+		case key.Matches(msg, m.ctx.Keys.Unselect) && m.ctx.Api != nil:
+			return m, tea.Batch(com.InsertModeExitedCmd(), com.LoginCancelledCmd())
 		}
 	}
 
@@ -62,11 +98,28 @@ func (m Model) View() string {
 
 	style := m.ctx.Styles.LoginView.Style.Width(lipgloss.Width(inputRendered) + 4)
 
-	content := lipgloss.JoinVertical(
-		lipgloss.Left,
-		inputRendered,
-		"\nHint: The key will be saved in your systems keyring",
-	)
+	lines := []string{inputRendered}
+
+	if apiKey := strings.TrimSpace(m.input.Value()); apiKey != "" {
+		lines = append(lines, "\n"+m.endpoint(apiKey))
+	}
+
+	switch {
+	case m.validating:
+		lines = append(lines, "\nChecking key...")
+	case m.err != "":
+		lines = append(lines, "\n"+m.ctx.Styles.LoginView.Error.Render(m.err))
+	}
+
+	lines = append(lines, "\nHint: The key will be saved in your system's keyring,\nor in a file in the config directory if there is none")
+
+	// Keys from the command line or the environment cannot be replaced by us
+	if source := m.ctx.KeyOverride; source != "" {
+		note := fmt.Sprintf("Note: The key is only used until you quit.\nAfterwards, the key from %s is used again.", source)
+		lines = append(lines, "\n"+m.ctx.Styles.LoginView.Error.Render(note))
+	}
+
+	content := lipgloss.JoinVertical(lipgloss.Left, lines...)
 
 	return lipgloss.Place(
 		m.contentWidth, m.contentHeight,
@@ -75,3 +128,28 @@ func (m Model) View() string {
 		lipgloss.WithWhitespaceChars(" "),
 	)
 }
+
+// Describe the DeepL plan and endpoint the key will be used with
+func (m Model) endpoint(apiKey string) string {
+	tier := "Pro"
+	if deeplapi.IsFreeKey(apiKey) {
+		tier = "Free"
+	}
+
+	_, endpoint := m.ctx.NewTranslator(apiKey)
+	return fmt.Sprintf("%s API: %s", tier, endpoint)
+}
+
+// Helper function to explain why a key could not be verified
+func describe(err error) string {
+	switch {
+	case deeplapi.IsAuthFailed(err):
+		return "DeepL rejected this key. Please check it for typos. Keys of the free plan end with ':fx'."
+	case deeplapi.IsRateLimited(err):
+		return "DeepL received too many requests. Please wait a moment and try again."
+	case deeplapi.IsServerError(err):
+		return "DeepL is temporarily unavailable. Please try again later."
+	default:
+		return fmt.Sprintf("Could not verify key: %v", err)
+	}
+}
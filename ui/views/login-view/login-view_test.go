@@ -0,0 +1,102 @@
+package loginview
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/leschuster/deepl-cli/pkg/auth"
+	deeplapi "github.com/leschuster/deepl-cli/pkg/deepl-api"
+	"github.com/leschuster/deepl-cli/ui/com"
+	"github.com/leschuster/deepl-cli/ui/context"
+)
+
+// Helper function to get a login view that is ready for input
+func newTestModel() Model {
+	ctx := context.New()
+	ctx.NewTranslator = func(apiKey string) (deeplapi.Translator, string) {
+		return deeplapi.New(apiKey), "https://api-free.deepl.com/v2"
+	}
+	ctx.ContentWidth, ctx.ContentHeight = 100, 30
+
+	m := InitialModel(ctx)
+	model, _ := m.Update(com.ContentSizeMsg{})
+	return model.(Model)
+}
+
+// Helper function to send a message to the model
+func update(m Model, msg tea.Msg) (Model, tea.Cmd) {
+	model, cmd := m.Update(msg)
+	return model.(Model), cmd
+}
+
+// Helper function to type a key and submit it, returning the entered key if any
+func submit(t *testing.T, m Model, apiKey string) (Model, string) {
+	t.Helper()
+
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(apiKey)})
+	m, cmd := update(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		return m, ""
+	}
+
+	msg, ok := cmd().(com.APIKeyEnteredMsg)
+	if !ok {
+		return m, ""
+	}
+	return m, msg.Key
+}
+
+func TestValidateChangeKeyValidate(t *testing.T) {
+	m := newTestModel()
+
+	m, entered := submit(t, m, "first-key:fx")
+	if entered != "first-key:fx" {
+		t.Fatalf("got entered key %q, want first-key:fx", entered)
+	}
+	m, _ = update(m, com.APIKeyValidatedMsg{Key: entered})
+
+	m, _ = update(m, com.APIKeyChangeRequestedMsg{})
+	if _, entered = submit(t, m, "second-key:fx"); entered != "second-key:fx" {
+		t.Errorf("got entered key %q after changing the key, want second-key:fx", entered)
+	}
+}
+
+func TestLoginAgainAfterValidation(t *testing.T) {
+	m := newTestModel()
+
+	m, entered := submit(t, m, "first-key:fx")
+	m, _ = update(m, com.APIKeyValidatedMsg{Key: entered})
+
+	// E.g. a profile without a key is selected, which only initializes the view
+	if _, entered = submit(t, m, "second-key:fx"); entered != "second-key:fx" {
+		t.Errorf("got entered key %q, want second-key:fx", entered)
+	}
+}
+
+func TestRejectedKeyCanBeCorrected(t *testing.T) {
+	m := newTestModel()
+
+	m, _ = submit(t, m, "wrong-key")
+	m, _ = update(m, com.APIKeyRejectedMsg{Err: &deeplapi.APIError{StatusCode: deeplapi.StatusForbidden}})
+	if m.err == "" {
+		t.Error("expected the rejection to be shown")
+	}
+
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyBackspace})
+	if _, entered := submit(t, m, ":fx"); entered != "wrong-ke:fx" {
+		t.Errorf("got entered key %q, want the corrected key", entered)
+	}
+}
+
+func TestNoteOnOverride(t *testing.T) {
+	m := newTestModel()
+	if strings.Contains(m.View(), auth.EnvAPIKey) {
+		t.Fatal("expected no note without an override")
+	}
+
+	m.ctx.KeyOverride = auth.SourceEnv
+	if !strings.Contains(m.View(), auth.EnvAPIKey) {
+		t.Error("expected a note that DEEPL_AUTH_KEY takes precedence")
+	}
+}
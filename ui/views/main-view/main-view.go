@@ -8,10 +8,12 @@ import (
 	"github.com/leschuster/deepl-cli/ui/com"
 	formalitybtn "github.com/leschuster/deepl-cli/ui/components/button/formality-btn"
 	srclangbtn "github.com/leschuster/deepl-cli/ui/components/button/src-lang-btn"
+	taghandlingbtn "github.com/leschuster/deepl-cli/ui/components/button/tag-handling-btn"
 	tarlangbtn "github.com/leschuster/deepl-cli/ui/components/button/tar-lang-btn"
 	translatebtn "github.com/leschuster/deepl-cli/ui/components/button/translate-btn"
 	"github.com/leschuster/deepl-cli/ui/components/layout"
 	textareadelimiter "github.com/leschuster/deepl-cli/ui/components/textarea-delimiter"
+	ctxtextarea "github.com/leschuster/deepl-cli/ui/components/textarea/ctx-textarea"
 	srctextarea "github.com/leschuster/deepl-cli/ui/components/textarea/src-textarea"
 	tartextarea "github.com/leschuster/deepl-cli/ui/components/textarea/tar-textarea"
 	"github.com/leschuster/deepl-cli/ui/context"
@@ -24,14 +26,16 @@ type Model struct {
 }
 
 func InitialModel(ctx *context.ProgramContext) Model {
-	var srcLangBtn, tarLangBtn, formalityBtn, translateBtn layout.LayoutModel
+	var srcLangBtn, tarLangBtn, formalityBtn, tagHandlingBtn, translateBtn layout.LayoutModel
 	srcLangBtn = srclangbtn.InitialModel(ctx)
 	tarLangBtn = tarlangbtn.InitialModel(ctx)
 	formalityBtn = formalitybtn.InitialModel(ctx)
+	tagHandlingBtn = taghandlingbtn.InitialModel(ctx)
 	translateBtn = translatebtn.InitialModel(ctx)
 
-	var srcTextArea, tarTextArea, delimiter layout.LayoutModel
+	var srcTextArea, ctxTextArea, tarTextArea, delimiter layout.LayoutModel
 	srcTextArea = srctextarea.InitialModel(ctx)
+	ctxTextArea = ctxtextarea.InitialModel(ctx)
 	tarTextArea = tartextarea.InitialModel(ctx)
 	delimiter = textareadelimiter.InitialModel(ctx)
 
@@ -50,10 +54,16 @@ func InitialModel(ctx *context.ProgramContext) Model {
 			layout.Empty(),
 		),
 		layout.NewRow(
-			layout.FillAuto(&translateBtn, layout.Center),
+			layout.Fill(&ctxTextArea, layout.Left, 0.5),
+			layout.Empty(),
+			layout.Empty(),
 			layout.Empty(),
+		),
+		layout.NewRow(
+			layout.FillAuto(&translateBtn, layout.Center),
 			layout.Empty(),
 			layout.Empty(),
+			layout.Fill(&tagHandlingBtn, layout.Right, 0.25),
 		),
 	)
 
@@ -0,0 +1,103 @@
+// Package profileview provides the view where the user is able to switch
+// to the API key of another profile.
+
+package profileview
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/leschuster/deepl-cli/ui/com"
+	"github.com/leschuster/deepl-cli/ui/components/list"
+	"github.com/leschuster/deepl-cli/ui/context"
+)
+
+type Model struct {
+	ctx                         *context.ProgramContext
+	list                        list.Model[string]
+	contentWidth, contentHeight int
+}
+
+func InitialModel(ctx *context.ProgramContext) Model {
+	return Model{
+		ctx:  ctx,
+		list: list.InitialModel[string](ctx, "Select Profile:"),
+	}
+}
+
+func (m Model) Init() tea.Cmd {
+	// Profiles may have changed since the view has been opened the last time
+	profiles := m.ctx.Profiles
+
+	return func() tea.Msg {
+		return com.ProfilesLoadedMsg{Names: profiles}
+	}
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	var cmds []tea.Cmd
+
+	switch msg := msg.(type) {
+	case com.ContentSizeMsg:
+		m.contentWidth, m.contentHeight = m.ctx.ContentWidth, m.ctx.ContentHeight
+		w, h := m.calcListSize()
+		m.list.Resize(w, h)
+
+	case com.ProfilesLoadedMsg:
+		items := make([]list.Item[string], len(msg.Names))
+		for i, name := range msg.Names {
+			prefix := "" // Marks the active profile
+			if name == m.ctx.Profile {
+				prefix = "*"
+			}
+			items[i] = list.NewItem(name, prefix, name)
+		}
+		m.list.SetItems(items)
+
+	case tea.KeyMsg:
+		switch {
+		case m.list.IsFiltering():
+			// Keystrokes belong to the filter
+
+		case key.Matches(msg, m.ctx.Keys.Select):
+			// User selected a profile
+			item, ok := m.list.GetSelected()
+			if !ok || item == nil {
+				return m, nil
+			}
+
+			return m, com.ProfileSelectedCmd((*item).Data())
+
+		case key.Matches(msg, m.ctx.Keys.Unselect) && !m.list.IsFiltered():
+			// User wants to go back
+			return m, com.ProfilesClosedCmd()
+		}
+	}
+
+	l, cmd := m.list.Update(msg)
+	m.list = l.(list.Model[string])
+	cmds = append(cmds, cmd)
+
+	return m, tea.Batch(cmds...)
+}
+
+func (m Model) View() string {
+	style := m.ctx.Styles.LangView.Style
+
+	content := style.Render(m.list.View())
+
+	// Place content in the center of the screen
+	return lipgloss.Place(
+		m.contentWidth, m.contentHeight,
+		lipgloss.Center, lipgloss.Center,
+		content,
+		lipgloss.WithWhitespaceChars(" "),
+	)
+}
+
+func (m *Model) calcListSize() (width, height int) {
+	width = min(42, m.contentWidth)
+	height = max(20, int(0.75*float32(m.contentHeight))-4)
+	return
+}
@@ -32,7 +32,7 @@ func (m Model) Init() tea.Cmd {
 
 	if api := m.ctx.Api; api != nil {
 		cmds = append(cmds, com.StartLoadingCmd())
-		cmds = append(cmds, m.ctx.AvailableLanguages.LoadInitial(*api)) // Load available languages
+		cmds = append(cmds, m.ctx.AvailableLanguages.LoadInitial(api)) // Load available languages
 		return tea.Batch(cmds...)
 	} else {
 		return com.ThrowErr(fmt.Errorf("ctx.api is nil"))